@@ -0,0 +1,156 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gosnake/internal/config"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/cobra"
+)
+
+var configForce bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show, create and check the configuration file",
+	Long: `GoSnake reads its settings from config.toml in the user config directory
+($XDG_CONFIG_HOME/gosnake/config.toml on Linux). Top-level keys apply to every
+game, [profiles.<name>] tables override them when picked with --profile, and
+command line flags override both.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		cmd.SilenceUsage = true
+	},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective settings for the selected profile",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings, err := loadSettings(cmd)
+		if err != nil {
+			return err
+		}
+		path, _ := config.Path()
+		fmt.Printf("# file: %s\n", path)
+		if profileName != "" {
+			fmt.Printf("# profile: %s\n", profileName)
+		}
+		return toml.NewEncoder(os.Stdout).Encode(settings)
+	},
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented example configuration file",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.Path()
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(path); err == nil && !configForce {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(config.Template), 0644); err != nil {
+			return err
+		}
+		fmt.Println("Wrote", path)
+		return nil
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration file and all of its profiles",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.Path()
+		if err != nil {
+			return err
+		}
+		file, err := config.Load(path)
+		if err != nil {
+			return err
+		}
+		if err := file.Validate(); err != nil {
+			return fmt.Errorf("%s:\n%w", path, err)
+		}
+		fmt.Printf("%s is valid (%d profile(s)).\n", path, len(file.Profiles))
+		return nil
+	},
+}
+
+// loadSettings layers the built-in defaults, the config file, the selected
+// profile and the flags given on the command line, in that order.
+func loadSettings(cmd *cobra.Command) (config.Settings, error) {
+	path, err := config.Path()
+	if err != nil {
+		return config.Settings{}, err
+	}
+	file, err := config.Load(path)
+	if err != nil {
+		return config.Settings{}, err
+	}
+	fromFile, err := file.Resolve(profileName)
+	if err != nil {
+		return config.Settings{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	flags := config.Settings{}
+	changed := cmd.Flags().Changed
+	if changed("mode") {
+		flags.Mode = &gameMode
+	}
+	if changed("speed") {
+		flags.Speed = &speed
+	}
+	if changed("duration") {
+		flags.Duration = &duration
+	}
+	if changed("relaxed") {
+		flags.Relaxed = &relaxed
+	}
+	if changed("no-sound") {
+		sound := !noSound
+		flags.Sound = &sound
+	}
+	if changed("name") {
+		flags.Name = &playerName
+	}
+	if changed("width") {
+		flags.Width = &boardWidth
+	}
+	if changed("height") {
+		flags.Height = &boardHeight
+	}
+	if changed("theme") {
+		flags.Theme = &themeName
+	}
+	if changed("ascii") {
+		flags.ASCII = &asciiOnly
+	}
+
+	return config.Defaults().Merge(fromFile).Merge(flags), nil
+}
+
+func init() {
+	configInitCmd.Flags().BoolVar(&configForce, "force", false, "Overwrite an existing file")
+
+	configCmd.AddCommand(configShowCmd, configInitCmd, configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+}
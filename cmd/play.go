@@ -6,9 +6,12 @@
 package cmd
 
 import (
+	"fmt"
 	"gosnake/game"
+	"gosnake/internal/savegame"
+	"gosnake/internal/theme"
 	"gosnake/internal/util"
-	"time"
+	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -17,27 +20,75 @@ var playCmd = &cobra.Command{
 	Use:   "play",
 	Short: "Start playing Snake",
 	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := loadSettings(cmd)
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+
 		config := util.NewGameConfig()
-		config.Speed = time.Duration(speed) * time.Millisecond
-
-		switch gameMode {
-		case "nowalls":
-			config.Mode = util.NoWalls
-		case "maze":
-			config.Mode = util.Maze
-		case "powerups":
-			config.Mode = util.PowerUps
-		default:
-			config.Mode = util.Normal
+		if err := settings.Apply(config); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		if cmd.Flags().Changed("seed") {
+			config.Seed = seed
+		}
+		t, err := settings.LoadTheme()
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		keys, err := settings.Keymap()
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
 		}
 
 		game := game.NewGame(config)
-		game.SetRelaxedMode(relaxed)
-		game.InitSound(!noSound)
+		game.SetRelaxedMode(*settings.Relaxed)
+		game.SetTheme(t)
+		game.SetThemeLoader(func(name string) (*theme.Theme, error) {
+			s := settings
+			s.Theme = &name
+			return s.LoadTheme()
+		})
+		game.SetKeymap(keys)
+		game.SetQueueDepth(*settings.QueueDepth)
+		if settings.Name != nil {
+			game.SetPlayerName(*settings.Name)
+		}
+		if resume {
+			saved, err := savegame.Load()
+			if err != nil {
+				fmt.Println("Error:", err)
+				os.Exit(1)
+			}
+			if err := game.Resume(saved); err != nil {
+				fmt.Println("Error: saved game:", err)
+				os.Exit(1)
+			}
+		}
+		game.InitSound(*settings.Sound)
 		game.Start()
 	},
 }
 
+var (
+	seed        int64
+	playerName  string
+	boardWidth  int
+	boardHeight int
+	resume      bool
+	duration    int
+)
+
 func init() {
+	playCmd.Flags().Int64Var(&seed, "seed", 0, "Seed for food, maze and power-up placement (random if not set)")
+	playCmd.Flags().StringVarP(&playerName, "name", "n", "", "Player name for high scores (defaults to your user name)")
+	playCmd.Flags().IntVar(&boardWidth, "width", 0, "Board width in cells (default: derived from the terminal)")
+	playCmd.Flags().IntVar(&boardHeight, "height", 0, "Board height in cells (default: derived from the terminal)")
+	playCmd.Flags().IntVar(&duration, "duration", 120, "Time attack budget in seconds; each food adds 5")
+	playCmd.Flags().BoolVar(&resume, "resume", false, "Continue the game saved with the save key (X)")
 	rootCmd.AddCommand(playCmd)
 }
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"gosnake/game"
+	"gosnake/internal/replay"
+
+	"github.com/spf13/cobra"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Play back a recorded game",
+	Long: `Play back a game recorded by "gosnake play".
+
+Replays are saved automatically when a game ends. While watching:
+space/p pause, f fast-forward, . step one tick, left/right arrows seek,
+home restarts and q quits.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		r, err := replay.Load(args[0])
+		if err != nil {
+			fmt.Println("Error loading replay:", err)
+			os.Exit(1)
+		}
+		settings, err := loadSettings(cmd)
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		theme, err := settings.LoadTheme()
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		if err := game.PlayReplay(r, theme); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+}
@@ -15,11 +15,14 @@ import (
 )
 
 var (
-	gameMode string
-	speed    int
-	relaxed  bool
-	noSound  bool
-	rootCmd  = &cobra.Command{
+	gameMode    string
+	speed       int
+	relaxed     bool
+	noSound     bool
+	profileName string
+	themeName   string
+	asciiOnly   bool
+	rootCmd     = &cobra.Command{
 		Use:   "gosnake",
 		Short: "A terminal-based Snake game written in Go",
 		Long: `A terminal-based Snake game with various options:
@@ -27,9 +30,12 @@ var (
 - Maze mode: Navigate through a randomly generated maze, collectig food
 - No Walls Mode: There are no borders
 - PowerUps: Enhance your abilities with powerups
+- Time Attack: Eat as much as you can before the clock runs out
+- Survival: The walls close in, score by staying alive
 - Relaxed mode: Speed remains constant accross all game modes
 - Custom starting speed`,
-		Version: util.VER,
+		Version:       util.VER,
+		SilenceErrors: true, // Execute prints them
 	}
 )
 
@@ -41,8 +47,11 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&gameMode, "mode", "m", "normal", "Game mode (normal, nowalls, maze, powerups)")
+	rootCmd.PersistentFlags().StringVarP(&gameMode, "mode", "m", "normal", "Game mode (normal, nowalls, maze, powerups, timeattack, survival)")
 	rootCmd.PersistentFlags().IntVarP(&speed, "speed", "s", 200, "Initial game speed (milliseconds)")
 	rootCmd.PersistentFlags().BoolVarP(&relaxed, "relaxed", "r", false, "Enable relaxed mode (constant speed)")
 	rootCmd.PersistentFlags().BoolVar(&noSound, "no-sound", false, "Disable sound")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Config file profile to use")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", "", "Theme: a built-in theme, a file in the themes directory or a .toml path")
+	rootCmd.PersistentFlags().BoolVar(&asciiOnly, "ascii", false, "Draw with plain ASCII glyphs only (default: detected from the locale)")
 }
@@ -0,0 +1,293 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gosnake/internal/highscore"
+	"gosnake/internal/util"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	scoresPlayer string
+	scoresSince  string
+	scoresUntil  string
+	scoresLimit  int
+	scoresJSON   bool
+	scoresYes    bool
+	scoresFormat string
+	scoresOutput string
+)
+
+var scoresCmd = &cobra.Command{
+	Use:   "scores",
+	Short: "List, filter, export and import high scores",
+	Long: `List high scores, best first.
+
+Filter with --mode, --player, --since and --until (dates as YYYY-MM-DD).
+The same filters select what "scores clear" deletes and "scores export" writes.`,
+	Args: cobra.NoArgs,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Flags parsed fine; any error from here on is not a usage error.
+		cmd.SilenceUsage = true
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := loadFilteredScores(cmd)
+		if err != nil {
+			return err
+		}
+		if scoresLimit > 0 && len(entries) > scoresLimit {
+			entries = entries[:scoresLimit]
+		}
+		if scoresJSON {
+			return highscore.WriteJSON(os.Stdout, entries)
+		}
+		printScoreTable(os.Stdout, entries)
+		return nil
+	},
+}
+
+var scoresClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete the high scores matching the filters",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, filter, err := openScores(cmd)
+		if err != nil {
+			return err
+		}
+		entries, err := store.Load()
+		if err != nil {
+			return err
+		}
+		matched := filter.Apply(entries)
+		if len(matched) == 0 {
+			fmt.Println("No matching scores.")
+			return nil
+		}
+		if !scoresYes && !confirm(fmt.Sprintf("Delete %d score(s)?", len(matched))) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+
+		removed := 0
+		err = store.Update(func(entries []highscore.Entry) []highscore.Entry {
+			kept := entries[:0]
+			for _, e := range entries {
+				if filter.Match(e) {
+					removed++
+					continue
+				}
+				kept = append(kept, e)
+			}
+			return kept
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Deleted %d score(s).\n", removed)
+		return nil
+	},
+}
+
+var scoresExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the high scores matching the filters as CSV or JSON",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := loadFilteredScores(cmd)
+		if err != nil {
+			return err
+		}
+		format, err := scoresFileFormat(scoresOutput)
+		if err != nil {
+			return err
+		}
+
+		write := highscore.WriteCSV
+		if format == "json" {
+			write = highscore.WriteJSON
+		}
+		if scoresOutput == "" || scoresOutput == "-" {
+			return write(os.Stdout, entries)
+		}
+
+		file, err := os.Create(scoresOutput)
+		if err != nil {
+			return err
+		}
+		if err := write(file, entries); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	},
+}
+
+var scoresImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import high scores from a CSV or JSON export",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := scoresFileFormat(args[0])
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		var entries []highscore.Entry
+		if format == "json" {
+			entries, err = highscore.ReadJSON(file)
+		} else {
+			entries, err = highscore.ReadCSV(file)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+
+		store, err := highscore.Open()
+		if store == nil {
+			return err
+		}
+		added, err := store.Merge(entries)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Imported %d score(s), skipped %d already stored.\n", added, len(entries)-added)
+		return nil
+	},
+}
+
+func openScores(cmd *cobra.Command) (*highscore.Store, highscore.Filter, error) {
+	filter := highscore.Filter{Player: scoresPlayer}
+	if cmd.Flags().Changed("mode") {
+		mode, err := util.ParseGameMode(gameMode)
+		if err != nil {
+			return nil, filter, err
+		}
+		filter.Mode = &mode
+	}
+
+	var err error
+	if filter.Since, err = parseScoreDate(scoresSince); err != nil {
+		return nil, filter, fmt.Errorf("--since: %w", err)
+	}
+	if filter.Until, err = parseScoreDate(scoresUntil); err != nil {
+		return nil, filter, fmt.Errorf("--until: %w", err)
+	}
+	if !filter.Until.IsZero() && !strings.Contains(scoresUntil, "T") {
+		// A bare date includes the whole day.
+		filter.Until = filter.Until.AddDate(0, 0, 1)
+	}
+
+	store, err := highscore.Open()
+	if store == nil {
+		return nil, filter, err
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning:", err)
+	}
+	return store, filter, nil
+}
+
+func loadFilteredScores(cmd *cobra.Command) ([]highscore.Entry, error) {
+	store, filter, err := openScores(cmd)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	return filter.Apply(entries), nil
+}
+
+func parseScoreDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func scoresFileFormat(path string) (string, error) {
+	format := strings.ToLower(scoresFormat)
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	switch format {
+	case "json", "csv":
+		return format, nil
+	case "":
+		return "csv", nil
+	default:
+		return "", fmt.Errorf("unknown format %q (use csv or json)", format)
+	}
+}
+
+func printScoreTable(w io.Writer, entries []highscore.Entry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No scores yet.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tPLAYER\tSCORE\tMODE\tSPEED\tBUDGET\tRELAXED\tLENGTH\tBOARD\tDURATION\tWON\tDATE")
+	for i, e := range entries {
+		if e.Legacy {
+			fmt.Fprintf(tw, "%d\t%s\t%d\t-\t-\t-\t-\t-\t-\t-\t-\t%s\n", i+1, e.Player, e.Score, e.Time.Format("2006-01-02 15:04"))
+			continue
+		}
+		budget := "-"
+		if b := e.Board().Budget; b > 0 {
+			budget = b.String()
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%dms\t%s\t%t\t%d\t%dx%d\t%s\t%t\t%s\n",
+			i+1, e.Player, e.Score, e.Mode, e.SpeedMS, budget, e.Relaxed, e.Length, e.Width, e.Height,
+			e.Duration().Round(time.Second), e.Won, e.Time.Format("2006-01-02 15:04"))
+	}
+	tw.Flush()
+}
+
+func confirm(question string) bool {
+	fmt.Print(question + " [y/N] ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func init() {
+	scoresCmd.PersistentFlags().StringVar(&scoresPlayer, "player", "", "Only scores by this player")
+	scoresCmd.PersistentFlags().StringVar(&scoresSince, "since", "", "Only scores from this date on (YYYY-MM-DD)")
+	scoresCmd.PersistentFlags().StringVar(&scoresUntil, "until", "", "Only scores up to this date (YYYY-MM-DD)")
+	scoresCmd.Flags().IntVar(&scoresLimit, "limit", 0, "Show at most this many scores")
+	scoresCmd.Flags().BoolVar(&scoresJSON, "json", false, "Print scores as JSON")
+
+	scoresClearCmd.Flags().BoolVarP(&scoresYes, "yes", "y", false, "Do not ask for confirmation")
+
+	scoresExportCmd.Flags().StringVarP(&scoresOutput, "output", "o", "", "Write to this file instead of stdout")
+	scoresExportCmd.Flags().StringVarP(&scoresFormat, "format", "f", "", "csv or json (default: from the file extension, else csv)")
+	scoresImportCmd.Flags().StringVarP(&scoresFormat, "format", "f", "", "csv or json (default: from the file extension)")
+
+	scoresCmd.AddCommand(scoresClearCmd, scoresExportCmd, scoresImportCmd)
+	rootCmd.AddCommand(scoresCmd)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package engine
+
+import "gosnake/internal/util"
+
+// body holds the snake's cells, head first, in a ring buffer, so a move
+// costs the same however long the snake is.
+type body struct {
+	cells      []util.Position
+	head, size int
+}
+
+func (b *body) pushHead(pos util.Position) {
+	if b.size == len(b.cells) {
+		b.grow()
+	}
+	b.head = (b.head - 1 + len(b.cells)) % len(b.cells)
+	b.cells[b.head] = pos
+	b.size++
+}
+
+func (b *body) popTail() util.Position {
+	b.size--
+	return b.cells[(b.head+b.size)%len(b.cells)]
+}
+
+func (b *body) length() int {
+	return b.size
+}
+
+func (b *body) grow() {
+	cells := make([]util.Position, max(2*len(b.cells), 16))
+	for i := 0; i < b.size; i++ {
+		cells[i] = b.cells[(b.head+i)%len(b.cells)]
+	}
+	b.cells, b.head = cells, 0
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package engine
+
+import (
+	"fmt"
+	"gosnake/internal/util"
+	"math/rand/v2"
+)
+
+// freeCells is the set of empty cells. Adding, removing and picking a
+// random one are O(1), so spawning does not slow down as the board fills.
+type freeCells struct {
+	width int
+	cells []int // x*width + y
+	index []int // where each cell is in cells, or -1
+}
+
+func newFreeCells(board util.Board, width int) freeCells {
+	f := freeCells{width: width, index: make([]int, len(board)*width)}
+	for x, row := range board {
+		for y, cell := range row {
+			f.index[x*width+y] = -1
+			f.update(x, y, cell.Empty())
+		}
+	}
+	return f
+}
+
+func (f *freeCells) update(x, y int, free bool) {
+	cell := x*f.width + y
+	i := f.index[cell]
+	switch {
+	case free && i < 0:
+		f.index[cell] = len(f.cells)
+		f.cells = append(f.cells, cell)
+	case !free && i >= 0:
+		last := f.cells[len(f.cells)-1]
+		f.cells[i] = last
+		f.index[last] = i
+		f.cells = f.cells[:len(f.cells)-1]
+		f.index[cell] = -1
+	}
+}
+
+// reorder puts the cells in the given order, which must hold exactly the
+// same cells.
+func (f *freeCells) reorder(cells []int) error {
+	if len(cells) != len(f.cells) {
+		return fmt.Errorf("%d free cells listed, the board has %d", len(cells), len(f.cells))
+	}
+	seen := make(map[int]bool, len(cells))
+	for _, cell := range cells {
+		if cell < 0 || cell >= len(f.index) || f.index[cell] < 0 || seen[cell] {
+			return fmt.Errorf("cell %d is not free", cell)
+		}
+		seen[cell] = true
+	}
+	for i, cell := range cells {
+		f.index[cell] = i
+	}
+	f.cells = append(f.cells[:0], cells...)
+	return nil
+}
+
+func (f *freeCells) random(r *rand.Rand) (int, int, bool) {
+	if len(f.cells) == 0 {
+		return 0, 0, false
+	}
+	cell := f.cells[r.IntN(len(f.cells))]
+	return cell / f.width, cell % f.width, true
+}
+
+// Every change to a board cell goes through these, to keep the free cells
+// and the food up to date.
+
+func (e *Engine) setTerrain(x, y int, terrain util.Terrain) {
+	e.State.Board[x][y].Terrain = terrain
+	e.free.update(x, y, e.State.Board[x][y].Empty())
+}
+
+func (e *Engine) setItem(x, y int, item util.Item) {
+	e.State.Board[x][y].Item = item
+	if item.Kind == util.ItemFood {
+		e.food = append(e.food, util.Position{X: x, Y: y})
+	}
+	e.free.update(x, y, e.State.Board[x][y].Empty())
+}
+
+func (e *Engine) setOccupant(x, y int, occupant util.Occupant) {
+	e.State.Board[x][y].Occupant = occupant
+	e.free.update(x, y, e.State.Board[x][y].Empty())
+}
+
+// randomFreeCell picks an empty cell, or reports that there is none.
+func (e *Engine) randomFreeCell() (int, int, bool) {
+	return e.free.random(e.State.Rand)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// Package engine advances a game of snake one tick at a time. It never
+// touches the terminal, the keyboard or the speakers, so it can be driven
+// by the interactive game, bots, servers or tests alike.
+package engine
+
+import (
+	"gosnake/internal/util"
+	"math/rand/v2"
+)
+
+// Input is what the player asked for during a tick. A zero Direction keeps
+// the snake going the way it already moves.
+type Input struct {
+	Direction int
+}
+
+// Events reports what happened during a single Step.
+type Events struct {
+	FoodEaten        bool
+	PowerUpCollected bool
+	PowerUp          util.PowerUpType
+	SpeedChanged     bool
+	GameOver         bool
+	Collision        int
+	Won              bool
+}
+
+type Engine struct {
+	State    util.GameState
+	PowerMgr util.GamePowerMgr
+	source   *rand.PCG // behind State.Rand
+	body     body
+	free     freeCells
+	food     []util.Position
+}
+
+func New(Config *util.GameConfig) *Engine {
+	source := util.NewSource(Config.Seed)
+	board := util.InitializeBoard(Config.TermWidth, Config.TermHeight)
+	return &Engine{
+		source: source,
+		free:   newFreeCells(board, Config.TermWidth),
+		State: util.GameState{
+			Config:      Config,
+			Snake:       util.NewSnake(),
+			Rand:        rand.New(source),
+			Board:       board,
+			Score:       0,
+			ExitGame:    false,
+			ExitCode:    0,
+			PauseGame:   false,
+			RelaxedMode: false,
+		},
+
+		PowerMgr: util.GamePowerMgr{
+			GhostMode:       false,
+			PointMultiplier: 1,
+			ActivePowerUps:  make([]*util.PowerUp, 0),
+		},
+	}
+}
+
+func (e *Engine) Init() {
+	e.occupyHead()
+	e.State.Deadline = e.State.Config.Duration
+
+	switch e.State.Config.Mode {
+	case util.Maze:
+		e.generateMaze()
+	case util.PowerUps:
+		e.spawnPowerUp()
+	}
+
+	e.placeFood()
+}
+
+// Step advances the game by exactly one tick.
+func (e *Engine) Step(in Input) Events {
+	var ev Events
+	if e.State.ExitGame {
+		ev.GameOver = true
+		ev.Collision = e.State.ExitCode
+		return ev
+	}
+
+	speed := e.State.Config.Speed
+	e.State.Clock += speed
+	e.turn(in.Direction)
+	e.moveSnake()
+
+	col := e.checkCollision()
+	if col != util.CollisionNone {
+		e.State.ExitCode = col
+		e.State.ExitGame = true
+		ev.GameOver = true
+		ev.Collision = col
+		return ev
+	}
+
+	e.updateBoard(&ev)
+	if e.State.Config.Mode == util.Survival {
+		e.updateSurvival(speed)
+		if e.State.Board[e.State.Snake.Headx][e.State.Snake.Heady].Terrain == util.TerrainClosed {
+			e.State.ExitCode = util.CollisionWall
+			e.State.ExitGame = true
+			ev.GameOver = true
+			ev.Collision = util.CollisionWall
+			return ev
+		}
+	}
+	if len(e.food) == 0 {
+		// There was no free cell left for new food.
+		e.State.ExitCode = util.Victory
+		e.State.ExitGame = true
+		ev.GameOver = true
+		ev.Won = true
+		return ev
+	}
+	if e.State.Config.Mode == util.TimeAttack && e.State.Clock >= e.State.Deadline {
+		e.State.ExitCode = util.TimeUp
+		e.State.ExitGame = true
+		ev.GameOver = true
+		return ev
+	}
+	if e.State.Score%util.MODSPEED == 0 && e.State.Score > 0 && !e.State.RelaxedMode {
+		e.State.Config.Speed -= util.MODSPEED
+	}
+
+	ev.SpeedChanged = e.State.Config.Speed != speed
+	return ev
+}
+
+func (e *Engine) turn(direction int) {
+	if direction < util.DirectionUp || direction > util.DirectionLeft {
+		return
+	}
+	if direction == util.OppositeDirection(e.State.Snake.Direction) {
+		return
+	}
+	e.State.Snake.Direction = direction
+}
+
+func (e *Engine) placeFood() {
+	e.clearEatenApples()
+
+	x, y, ok := e.randomFreeCell()
+	if !ok {
+		return
+	}
+	e.setItem(x, y, util.Item{Kind: util.ItemFood})
+}
+
+// clearEatenApples removes the food left on the board, so there is only
+// ever the one just placed.
+func (e *Engine) clearEatenApples() {
+	food := e.food
+	e.food = nil
+	for _, pos := range food {
+		if e.State.Board[pos.X][pos.Y].Item.Kind == util.ItemFood {
+			e.setItem(pos.X, pos.Y, util.Item{})
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"gosnake/internal/util"
+)
+
+// steer picks the next move for a test game: towards the food, avoiding
+// anything the snake would crash into. It only looks at the engine's state,
+// so engines in the same state get the same input.
+func steer(e *Engine) Input {
+	board, snake := e.State.Board, e.State.Snake
+	foodX, foodY := snake.Headx, snake.Heady
+	for x, row := range board {
+		for y, cell := range row {
+			if cell.Item.Kind == util.ItemFood {
+				foodX, foodY = x, y
+			}
+		}
+	}
+
+	moves := [...]util.Position{util.DirectionUp: {X: -1}, util.DirectionRight: {Y: 1}, util.DirectionDown: {X: 1}, util.DirectionLeft: {Y: -1}}
+	best, bestDistance := snake.Direction, -1
+	for direction := util.DirectionUp; direction <= util.DirectionLeft; direction++ {
+		if direction == util.OppositeDirection(snake.Direction) {
+			continue
+		}
+		x, y := snake.Headx+moves[direction].X, snake.Heady+moves[direction].Y
+		if x < 0 || x >= len(board) || y < 0 || y >= len(board[x]) {
+			continue
+		}
+		if cell := board[x][y]; cell.Terrain != util.TerrainOpen || cell.Occupied() {
+			continue
+		}
+		distance := max(x-foodX, foodX-x) + max(y-foodY, foodY-y)
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = direction, distance
+		}
+	}
+	return Input{Direction: best}
+}
+
+func newTestEngine(mode util.GameMode, seed int64) *Engine {
+	config := util.DefaultGameConfig()
+	config.TermWidth, config.TermHeight = 40, 20
+	config.Mode = mode
+	config.Seed = seed
+	e := New(config)
+	e.Init()
+	return e
+}
+
+// stepBoth feeds the same inputs to a and b for up to ticks ticks and
+// fails as soon as they differ.
+func stepBoth(t *testing.T, a, b *Engine, ticks int) {
+	t.Helper()
+	for tick := 0; tick < ticks && !a.State.ExitGame; tick++ {
+		in := steer(a)
+		eventsA, eventsB := a.Step(in), b.Step(in)
+		if eventsA != eventsB {
+			t.Fatalf("tick %d: events %+v and %+v", tick, eventsA, eventsB)
+		}
+		if !reflect.DeepEqual(a.Snapshot(), b.Snapshot()) {
+			t.Fatalf("tick %d: the games differ", tick)
+		}
+	}
+}
+
+// TestDeterministic checks that a seed and the inputs are all it takes to
+// play a game again, which replays rely on.
+func TestDeterministic(t *testing.T) {
+	for _, mode := range util.GameModes() {
+		t.Run(mode.String(), func(t *testing.T) {
+			for seed := int64(1); seed <= 5; seed++ {
+				stepBoth(t, newTestEngine(mode, seed), newTestEngine(mode, seed), 500)
+			}
+		})
+	}
+}
+
+var benchSizes = []struct{ width, height int }{
+	{40, 20},
+	{200, 100},
+	{500, 500},
+}
+
+// tour is a cycle through every cell of a board with an even height: along
+// the top row, back and forth over the other rows leaving out the first
+// column, then up the first column. A snake following it never dies.
+func tour(width, height int) [][]int {
+	next := make([][]int, height)
+	for x := range next {
+		next[x] = make([]int, width)
+		for y := range next[x] {
+			switch {
+			case x == 0:
+				next[x][y] = util.DirectionRight
+				if y == width-1 {
+					next[x][y] = util.DirectionDown
+				}
+			case y == 0:
+				next[x][y] = util.DirectionUp
+			case x%2 == 1:
+				next[x][y] = util.DirectionLeft
+				if y == 1 {
+					next[x][y] = util.DirectionDown
+				}
+			default:
+				next[x][y] = util.DirectionRight
+				if y == width-1 {
+					next[x][y] = util.DirectionDown
+				}
+			}
+		}
+	}
+	// The last row, an odd one, leads into the first column.
+	next[height-1][1] = util.DirectionLeft
+	return next
+}
+
+// newBenchEngine starts a game on a width x height board whose snake is
+// free cells short of filling it. benchmarkStep holds it at that length.
+func newBenchEngine(width, height, free int) (*Engine, [][]int) {
+	config := util.DefaultGameConfig()
+	config.TermWidth, config.TermHeight = width, height
+	config.Seed = 1
+	e := New(config)
+	e.State.RelaxedMode = true
+	e.Init()
+
+	path := tour(width, height)
+	e.State.Snake.Length = max(width*height-free, 1)
+	for e.body.length() < e.State.Snake.Length && !e.State.ExitGame {
+		e.Step(Input{Direction: path[e.State.Snake.Headx][e.State.Snake.Heady]})
+	}
+	return e, path
+}
+
+func benchmarkStep(b *testing.B, width, height, free int) {
+	e, path := newBenchEngine(width, height, free)
+	length := e.State.Snake.Length
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e.State.Snake.Length = length
+		e.Step(Input{Direction: path[e.State.Snake.Headx][e.State.Snake.Heady]})
+	}
+	b.StopTimer()
+	if e.State.ExitGame {
+		b.Fatalf("game over: %d", e.State.ExitCode)
+	}
+}
+
+// BenchmarkStep measures a tick with a short snake on boards of growing
+// size. It should not grow with the board.
+func BenchmarkStep(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(b *testing.B) {
+			benchmarkStep(b, size.width, size.height, size.width*size.height)
+		})
+	}
+}
+
+// BenchmarkStepNearFull measures a tick when the snake covers all but a
+// few cells, where finding a free cell for food used to be slowest.
+func BenchmarkStepNearFull(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(b *testing.B) {
+			benchmarkStep(b, size.width, size.height, 20)
+		})
+	}
+}
+
+// BenchmarkBoardScan is the baseline: one pass over every cell, the least
+// each tick cost when the snake was aged in place on the board.
+func BenchmarkBoardScan(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(b *testing.B) {
+			e, _ := newBenchEngine(size.width, size.height, size.width*size.height)
+			b.ResetTimer()
+			occupied := 0
+			for i := 0; i < b.N; i++ {
+				for _, row := range e.State.Board {
+					for _, cell := range row {
+						if cell.Occupied() {
+							occupied++
+						}
+					}
+				}
+			}
+			if occupied == 0 {
+				b.Fatal("no snake on the board")
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package engine
+
+import "gosnake/internal/util"
+
+func (e *Engine) moveSnake() {
+	newX, newY := e.State.Snake.Headx, e.State.Snake.Heady
+
+	switch e.State.Snake.Direction {
+	case 1:
+		newX--
+	case 2:
+		newY++
+	case 3:
+		newX++
+	case 4:
+		newY--
+	}
+
+	if e.State.Config.Mode == util.NoWalls || e.PowerMgr.GhostMode {
+		if newX < 0 {
+			newX = e.State.Config.TermHeight - 1
+		} else if newX >= e.State.Config.TermHeight {
+			newX = 0
+		}
+		if newY < 0 {
+			newY = e.State.Config.TermWidth - 1
+		} else if newY >= e.State.Config.TermWidth {
+			newY = 0
+		}
+	}
+
+	e.State.Snake.Headx, e.State.Snake.Heady = newX, newY
+}
+
+func (e *Engine) checkCollision() int {
+	if e.State.Config.Mode != util.NoWalls && !e.PowerMgr.GhostMode {
+		// Only survival closes rings in; the inset is 0 otherwise.
+		inset := e.State.Inset
+		if e.State.Snake.Headx < inset || e.State.Snake.Headx >= e.State.Config.TermHeight-inset ||
+			e.State.Snake.Heady < inset || e.State.Snake.Heady >= e.State.Config.TermWidth-inset {
+			return util.CollisionWall
+		}
+	}
+
+	cell := e.State.Board[e.State.Snake.Headx][e.State.Snake.Heady]
+	if cell.Terrain == util.TerrainWall && !e.PowerMgr.GhostMode {
+		return util.CollisionWall
+	}
+
+	if cell.Occupied() {
+		return util.CollisionSelf
+	}
+
+	return 0
+}
+
+func (e *Engine) updateBoard(ev *Events) {
+	x, y := e.State.Snake.Headx, e.State.Snake.Heady
+	item := e.State.Board[x][y].Item
+	e.setItem(x, y, util.Item{})
+	// The head is taken before anything spawns.
+	e.occupyHead()
+
+	switch item.Kind {
+	case util.ItemFood:
+		e.State.Score += (1 * e.PowerMgr.PointMultiplier)
+		e.State.Snake.Length++
+		ev.FoodEaten = true
+		e.placeFood()
+		if e.State.Config.Mode == util.TimeAttack {
+			e.State.Deadline += util.TimeAttackBonus
+		}
+
+		if e.State.Config.Mode == util.PowerUps {
+			e.spawnPowerUp()
+		}
+	case util.ItemPowerUp:
+		ev.PowerUpCollected = true
+		ev.PowerUp = item.PowerUp
+		e.activatePowerUp(ev.PowerUp)
+	}
+
+	e.updatePowerUps()
+
+	for e.body.length() > e.State.Snake.Length {
+		tail := e.body.popTail()
+		e.setOccupant(tail.X, tail.Y, util.Occupant{})
+	}
+}
+
+func (e *Engine) occupyHead() {
+	snake := e.State.Snake
+	snake.Moves++
+	e.setOccupant(snake.Headx, snake.Heady, util.Occupant{Snake: util.PlayerSnake, Seq: snake.Moves})
+	e.body.pushHead(util.Position{X: snake.Headx, Y: snake.Heady})
+}
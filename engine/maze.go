@@ -0,0 +1,49 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package engine
+
+import "gosnake/internal/util"
+
+func (e *Engine) generateMaze() {
+	e.State.Config.Obstacles = make([]util.Position, 0)
+
+	numObstacles := (e.State.Config.TermWidth * e.State.Config.TermHeight) / 10
+	for i := 0; i < numObstacles; i++ {
+		x, y, ok := e.randomFreeCell()
+		if !ok {
+			break
+		}
+		e.State.Config.Obstacles = append(e.State.Config.Obstacles, util.Position{X: x, Y: y})
+		e.setTerrain(x, y, util.TerrainWall)
+	}
+
+	e.ensurePlayableMaze()
+}
+
+func (e *Engine) ensurePlayableMaze() {
+	startX, startY := e.State.Snake.Headx, e.State.Snake.Heady
+	foodX, foodY, ok := e.randomFreeCell()
+	if !ok {
+		return
+	}
+	e.setItem(foodX, foodY, util.Item{Kind: util.ItemFood})
+
+	for x := min(startX, foodX); x <= max(startX, foodX); x++ {
+		e.setTerrain(x, startY, util.TerrainOpen)
+	}
+	for y := min(startY, foodY); y <= max(startY, foodY); y++ {
+		e.setTerrain(foodX, y, util.TerrainOpen)
+	}
+
+	// Keep only the obstacles the path left standing.
+	obstacles := e.State.Config.Obstacles[:0]
+	for _, obs := range e.State.Config.Obstacles {
+		if e.State.Board[obs.X][obs.Y].Terrain == util.TerrainWall {
+			obstacles = append(obstacles, obs)
+		}
+	}
+	e.State.Config.Obstacles = obstacles
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package engine
+
+import (
+	"gosnake/internal/util"
+	"time"
+)
+
+func (e *Engine) spawnPowerUp() {
+	if e.State.Config.Mode != util.PowerUps {
+		return
+	}
+
+	if e.State.Rand.Float32() < 0.25 {
+		x, y, ok := e.randomFreeCell()
+		if !ok {
+			return
+		}
+		powerType := util.PowerUpType(-4 + e.State.Rand.IntN(5))
+		e.setItem(x, y, util.Item{Kind: util.ItemPowerUp, PowerUp: powerType})
+	}
+}
+
+const powerUpDuration = 10 * time.Second
+
+func (e *Engine) activatePowerUp(typ util.PowerUpType) {
+	powerUp := &util.PowerUp{
+		Type:     typ,
+		Duration: powerUpDuration,
+		Active:   true,
+		EndTime:  e.State.Clock + powerUpDuration,
+	}
+
+	switch typ {
+	case util.SpeedUp:
+		e.State.Config.Speed = e.State.Config.Speed / 2
+	case util.SlowDown:
+		e.State.Config.Speed = e.State.Config.Speed * 2
+	case util.GhostMode:
+		e.PowerMgr.GhostMode = true
+	case util.ExtraLength:
+		e.State.Snake.Length += 2
+	case util.DoublePoints:
+		e.PowerMgr.PointMultiplier = 2
+	}
+
+	e.PowerMgr.ActivePowerUps = append(e.PowerMgr.ActivePowerUps, powerUp)
+}
+
+func (e *Engine) updatePowerUps() {
+	for i := len(e.PowerMgr.ActivePowerUps) - 1; i >= 0; i-- {
+		if e.PowerMgr.ActivePowerUps[i].Remaining(e.State.Clock) == 0 {
+			switch e.PowerMgr.ActivePowerUps[i].Type {
+			case util.SpeedUp:
+				e.State.Config.Speed *= 2
+			case util.SlowDown:
+				e.State.Config.Speed /= 2
+			case util.GhostMode:
+				e.PowerMgr.GhostMode = false
+			case util.ExtraLength:
+				e.State.Snake.Length -= 2
+			case util.DoublePoints:
+				e.PowerMgr.PointMultiplier = 1
+			}
+			e.PowerMgr.ActivePowerUps = append(e.PowerMgr.ActivePowerUps[:i], e.PowerMgr.ActivePowerUps[i+1:]...)
+		}
+	}
+}
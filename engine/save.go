@@ -0,0 +1,162 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package engine
+
+import (
+	"fmt"
+	"gosnake/internal/util"
+	"math/rand/v2"
+	"sort"
+	"time"
+)
+
+// Saved is everything needed to continue a game later.
+type Saved struct {
+	Config          util.GameConfig `json:"config"`
+	Snake           util.Snake      `json:"snake"`
+	Cells           []SavedCell     `json:"cells"` // every cell that is not empty
+	Free            []int           `json:"free"`  // the empty cells in the order spawning picks from
+	Score           int             `json:"score"`
+	Clock           time.Duration   `json:"clock"`
+	Deadline        time.Duration   `json:"deadline"`
+	Inset           int             `json:"inset"`
+	Relaxed         bool            `json:"relaxed"`
+	GhostMode       bool            `json:"ghost_mode"`
+	PointMultiplier int             `json:"point_multiplier"`
+	PowerUps        []SavedPowerUp  `json:"power_ups"`
+	RNG             []byte          `json:"rng"`
+}
+
+type SavedCell struct {
+	X    int       `json:"x"`
+	Y    int       `json:"y"`
+	Cell util.Cell `json:"cell"`
+}
+
+type SavedPowerUp struct {
+	Type      util.PowerUpType `json:"type"`
+	Duration  time.Duration    `json:"duration"`
+	Remaining time.Duration    `json:"remaining"`
+}
+
+// Save captures the game as it is now.
+func (e *Engine) Save() (*Saved, error) {
+	rng, err := e.source.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Saved{
+		Config:          *e.State.Config,
+		Snake:           *e.State.Snake,
+		Score:           e.State.Score,
+		Clock:           e.State.Clock,
+		Deadline:        e.State.Deadline,
+		Inset:           e.State.Inset,
+		Relaxed:         e.State.RelaxedMode,
+		GhostMode:       e.PowerMgr.GhostMode,
+		PointMultiplier: e.PowerMgr.PointMultiplier,
+		RNG:             rng,
+		Free:            append([]int(nil), e.free.cells...),
+	}
+	s.Config.Obstacles = append([]util.Position(nil), e.State.Config.Obstacles...)
+	for x, row := range e.State.Board {
+		for y, cell := range row {
+			if !cell.Empty() {
+				s.Cells = append(s.Cells, SavedCell{X: x, Y: y, Cell: cell})
+			}
+		}
+	}
+	for _, powerUp := range e.PowerMgr.ActivePowerUps {
+		s.PowerUps = append(s.PowerUps, SavedPowerUp{
+			Type:      powerUp.Type,
+			Duration:  powerUp.Duration,
+			Remaining: powerUp.Remaining(e.State.Clock),
+		})
+	}
+	return s, nil
+}
+
+// Restore builds an engine that continues a saved game.
+func Restore(s *Saved) (*Engine, error) {
+	config := s.Config
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	board := util.InitializeBoard(config.TermWidth, config.TermHeight)
+	var segments []util.Position
+	var food []util.Position
+	for _, c := range s.Cells {
+		if c.X < 0 || c.X >= config.TermHeight || c.Y < 0 || c.Y >= config.TermWidth {
+			return nil, fmt.Errorf("cell %d,%d is off the board", c.X, c.Y)
+		}
+		board[c.X][c.Y] = c.Cell
+		if c.Cell.Occupant.Snake == util.PlayerSnake {
+			segments = append(segments, util.Position{X: c.X, Y: c.Y})
+		}
+		if c.Cell.Item.Kind == util.ItemFood {
+			food = append(food, util.Position{X: c.X, Y: c.Y})
+		}
+	}
+	snake := s.Snake
+	if snake.Headx < 0 || snake.Headx >= config.TermHeight || snake.Heady < 0 || snake.Heady >= config.TermWidth {
+		return nil, fmt.Errorf("snake head %d,%d is off the board", snake.Headx, snake.Heady)
+	}
+	if snake.Length < 1 {
+		return nil, fmt.Errorf("bad snake length %d", snake.Length)
+	}
+	if snake.Direction < util.DirectionUp || snake.Direction > util.DirectionLeft {
+		return nil, fmt.Errorf("bad snake direction %d", snake.Direction)
+	}
+
+	source := &rand.PCG{}
+	if err := source.UnmarshalBinary(s.RNG); err != nil {
+		return nil, fmt.Errorf("random state: %w", err)
+	}
+
+	free := newFreeCells(board, config.TermWidth)
+	if err := free.reorder(s.Free); err != nil {
+		return nil, err
+	}
+
+	e := &Engine{
+		source: source,
+		free:   free,
+		food:   food,
+		State: util.GameState{
+			Config:      &config,
+			Snake:       &snake,
+			Rand:        rand.New(source),
+			Board:       board,
+			Score:       s.Score,
+			Clock:       s.Clock,
+			Deadline:    s.Deadline,
+			Inset:       s.Inset,
+			RelaxedMode: s.Relaxed,
+		},
+		PowerMgr: util.GamePowerMgr{
+			GhostMode:       s.GhostMode,
+			PointMultiplier: s.PointMultiplier,
+			ActivePowerUps:  make([]*util.PowerUp, 0, len(s.PowerUps)),
+		},
+	}
+	// The body is rebuilt tail first, so the head ends up in front.
+	sort.Slice(segments, func(i, j int) bool {
+		return board[segments[i].X][segments[i].Y].Occupant.Seq < board[segments[j].X][segments[j].Y].Occupant.Seq
+	})
+	for _, pos := range segments {
+		e.body.pushHead(pos)
+	}
+	for _, powerUp := range s.PowerUps {
+		e.PowerMgr.ActivePowerUps = append(e.PowerMgr.ActivePowerUps, &util.PowerUp{
+			Type:     powerUp.Type,
+			Duration: powerUp.Duration,
+			Active:   true,
+			EndTime:  s.Clock + powerUp.Remaining,
+		})
+	}
+	return e, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gosnake/internal/util"
+)
+
+// TestSaveRestore checks that a game saved to JSON and restored goes on
+// exactly like the original, random decisions included.
+func TestSaveRestore(t *testing.T) {
+	for _, mode := range util.GameModes() {
+		t.Run(mode.String(), func(t *testing.T) {
+			e := newTestEngine(mode, 1)
+			for tick := 0; tick < 100 && !e.State.ExitGame; tick++ {
+				e.Step(steer(e))
+			}
+			if e.State.ExitGame {
+				t.Fatalf("game over before saving: %d", e.State.ExitCode)
+			}
+
+			saved, err := e.Save()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.Marshal(saved)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var loaded Saved
+			if err := json.Unmarshal(data, &loaded); err != nil {
+				t.Fatal(err)
+			}
+			restored, err := Restore(&loaded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stepBoth(t, e, restored, 200)
+		})
+	}
+}
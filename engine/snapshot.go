@@ -0,0 +1,54 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package engine
+
+import (
+	"gosnake/internal/util"
+	"time"
+)
+
+// Snapshot is a copy of everything a renderer needs to draw one frame.
+// Changing it does not affect the game.
+type Snapshot struct {
+	Config          util.GameConfig
+	Board           util.Board
+	Snake           util.Snake
+	Score           int
+	Clock           time.Duration
+	Deadline        time.Duration
+	Inset           int
+	Paused          bool
+	GameOver        bool
+	ExitCode        int
+	RelaxedMode     bool
+	GhostMode       bool
+	PointMultiplier int
+	ActivePowerUps  []util.PowerUp
+}
+
+func (e *Engine) Snapshot() *Snapshot {
+	s := &Snapshot{
+		Config:          *e.State.Config,
+		Board:           e.State.Board.Clone(),
+		Snake:           *e.State.Snake,
+		Score:           e.State.Score,
+		Clock:           e.State.Clock,
+		Deadline:        e.State.Deadline,
+		Inset:           e.State.Inset,
+		Paused:          e.State.PauseGame,
+		GameOver:        e.State.ExitGame,
+		ExitCode:        e.State.ExitCode,
+		RelaxedMode:     e.State.RelaxedMode,
+		GhostMode:       e.PowerMgr.GhostMode,
+		PointMultiplier: e.PowerMgr.PointMultiplier,
+		ActivePowerUps:  make([]util.PowerUp, len(e.PowerMgr.ActivePowerUps)),
+	}
+	s.Config.Obstacles = append([]util.Position(nil), e.State.Config.Obstacles...)
+	for i, powerUp := range e.PowerMgr.ActivePowerUps {
+		s.ActivePowerUps[i] = *powerUp
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package engine
+
+import (
+	"gosnake/internal/util"
+	"time"
+)
+
+// updateSurvival scores a point for every second survived and closes the
+// arena in when it is time to.
+func (e *Engine) updateSurvival(tick time.Duration) {
+	e.State.Score += int(e.State.Clock/time.Second - (e.State.Clock-tick)/time.Second)
+
+	if e.canShrink() && e.State.Clock >= time.Duration(e.State.Inset+1)*util.ShrinkInterval {
+		e.shrinkArena()
+	}
+}
+
+func (e *Engine) canShrink() bool {
+	width, height := e.arena()
+	return width-2 >= util.MinArena && height-2 >= util.MinArena
+}
+
+// arena is the size of the part of the board still in play.
+func (e *Engine) arena() (int, int) {
+	inset := e.State.Inset
+	return e.State.Config.TermWidth - 2*inset, e.State.Config.TermHeight - 2*inset
+}
+
+// shrinkArena closes the outermost ring of the arena. Whatever lies there
+// is lost; food is placed again inside.
+func (e *Engine) shrinkArena() {
+	inset := e.State.Inset
+	top, bottom := inset, e.State.Config.TermHeight-1-inset
+	left, right := inset, e.State.Config.TermWidth-1-inset
+
+	lostFood := false
+	closeCell := func(x, y int) {
+		if e.State.Board[x][y].Item.Kind == util.ItemFood {
+			lostFood = true
+		}
+		e.setItem(x, y, util.Item{})
+		e.setTerrain(x, y, util.TerrainClosed)
+	}
+	for y := left; y <= right; y++ {
+		closeCell(top, y)
+		closeCell(bottom, y)
+	}
+	for x := top + 1; x < bottom; x++ {
+		closeCell(x, left)
+		closeCell(x, right)
+	}
+	e.State.Inset++
+
+	if lostFood {
+		e.placeFood()
+	}
+}
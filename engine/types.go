@@ -0,0 +1,87 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package engine
+
+import "gosnake/internal/util"
+
+// The engine's API is built on the game's shared types, which live in an
+// internal package. They are re-exported here so code outside this module
+// can configure a game and read its state.
+
+type (
+	Config       = util.GameConfig
+	Mode         = util.GameMode
+	State        = util.GameState
+	PowerManager = util.GamePowerMgr
+	Snake        = util.Snake
+	Position     = util.Position
+	PowerUp      = util.PowerUp
+	PowerUpType  = util.PowerUpType
+	Board        = util.Board
+	Cell         = util.Cell
+	Terrain      = util.Terrain
+	Item         = util.Item
+	ItemKind     = util.ItemKind
+	Occupant     = util.Occupant
+)
+
+const (
+	Normal     = util.Normal
+	NoWalls    = util.NoWalls
+	Maze       = util.Maze
+	PowerUps   = util.PowerUps
+	TimeAttack = util.TimeAttack
+	Survival   = util.Survival
+)
+
+const (
+	DirectionUp    = util.DirectionUp
+	DirectionRight = util.DirectionRight
+	DirectionDown  = util.DirectionDown
+	DirectionLeft  = util.DirectionLeft
+)
+
+// Outcomes reported in Events.Collision and State.ExitCode.
+const (
+	CollisionNone = util.CollisionNone
+	CollisionWall = util.CollisionWall
+	CollisionSelf = util.CollisionSelf
+	Victory       = util.Victory
+	TimeUp        = util.TimeUp
+)
+
+const (
+	TerrainOpen   = util.TerrainOpen
+	TerrainWall   = util.TerrainWall
+	TerrainClosed = util.TerrainClosed
+)
+
+const (
+	ItemNone    = util.ItemNone
+	ItemFood    = util.ItemFood
+	ItemPowerUp = util.ItemPowerUp
+)
+
+const (
+	SpeedUp      = util.SpeedUp
+	SlowDown     = util.SlowDown
+	GhostMode    = util.GhostMode
+	ExtraLength  = util.ExtraLength
+	DoublePoints = util.DoublePoints
+)
+
+// DefaultConfig is a normal game on a 40x20 board with a random seed.
+// Set TermWidth and TermHeight for another size.
+func DefaultConfig() *Config {
+	config := util.DefaultGameConfig()
+	config.TermWidth, config.TermHeight = 40, 20
+	return config
+}
+
+// ParseMode looks a mode up by the name the --mode flag takes.
+func ParseMode(name string) (Mode, error) {
+	return util.ParseGameMode(name)
+}
@@ -7,49 +7,62 @@ package game
 
 import (
 	"fmt"
-	"math/rand"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"gosnake/engine"
+	"gosnake/internal/keymap"
+	"gosnake/internal/replay"
+	"gosnake/internal/theme"
 	"gosnake/internal/util"
 
 	"github.com/eiannone/keyboard"
 )
 
 type Game struct {
-	State     util.GameState
-	inputChan chan keyboard.KeyEvent
-	PowerMgr  util.GamePowerMgr
-	sound     *SoundManager
+	*engine.Engine
+	inputChan  chan keyboard.KeyEvent
+	sound      *SoundManager
+	turns      turnQueue
+	recorder   *replay.Recorder
+	renderer   Renderer
+	theme      *theme.Theme
+	loadTheme  func(name string) (*theme.Theme, error)
+	keymap     keymap.Keymap
+	restart    bool
+	saveGame   bool
+	resumed    bool
+	resized    <-chan struct{}
+	tooSmall   bool
+	playerName string
+	start      util.GameConfig // as the round began, before speed changes
+	endTime    time.Time
 }
 
 func NewGame(Config *util.GameConfig) *Game {
 	return &Game{
-		State: util.GameState{
-			Config:      Config,
-			Snake:       util.NewSnake(),
-			Board:       util.InitializeBoard(Config.TermWidth, Config.TermHeight),
-			Score:       0,
-			ExitGame:    false,
-			ExitCode:    0,
-			PauseGame:   false,
-			RelaxedMode: false,
-		},
+		Engine: engine.New(Config),
 
 		inputChan: make(chan keyboard.KeyEvent, 10),
 
-		PowerMgr: util.GamePowerMgr{
-			GhostMode:       false,
-			PointMultiplier: 1,
-			ActivePowerUps:  make([]*util.PowerUp, 0),
-		},
-
 		sound: NewSoundManager(false),
+
+		theme:     defaultTheme(),
+		loadTheme: theme.Load,
+		keymap:    keymap.Default(),
+		turns:     newTurnQueue(util.DefaultQueueDepth),
+
+		playerName: util.DefaultPlayerName(),
 	}
 }
 
+func defaultTheme() *theme.Theme {
+	t, _ := theme.Builtin(theme.Default)
+	return t
+}
+
 func killSig() {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -61,58 +74,126 @@ func killSig() {
 	}()
 }
 
-func (g *Game) placeFood() {
-	g.clearEatenApples()
+func (g *Game) runGameLoop() {
+	ticker := time.NewTicker(g.State.Config.Speed)
+	defer ticker.Stop()
 
-	x, y := g.getRandomEmptyPosition()
-	g.State.Board[x][y] = -1
-}
+	if g.renderer == nil {
+		r := NewRenderer(g.theme)
+		r.PauseKey = g.keymap.Describe(keymap.Pause)
+		g.renderer = r
+	}
+	resized, stopResize := watchResize()
+	defer stopResize()
+	g.resized = resized
+	g.handleResize()
 
-func (g *Game) clearEatenApples() {
-	for x := 0; x < g.State.Config.TermHeight; x++ {
-		for y := 0; y < g.State.Config.TermWidth; y++ {
-			if g.State.Board[x][y] == -1 {
-				g.State.Board[x][y] = 0
+	for !g.State.ExitGame {
+		g.detectPause()
+
+		select {
+		case event := <-g.inputChan:
+			g.handleInput(event)
+			g.renderer.Render(g.Snapshot())
+		case <-g.resized:
+			g.handleResize()
+		case <-ticker.C:
+			if g.update().SpeedChanged {
+				ticker.Reset(g.State.Config.Speed)
+			}
+			if !g.State.ExitGame {
+				g.renderer.Render(g.Snapshot())
 			}
 		}
 	}
+
+	g.endTime = time.Now()
 }
 
-func (g *Game) getRandomEmptyPosition() (int, int) {
-	for {
-		x := rand.Intn(g.State.Config.TermHeight)
-		y := rand.Intn(g.State.Config.TermWidth)
-		if g.State.Board[x][y] == 0 {
-			return x, y
-		}
+func (g *Game) saveReplay() (string, error) {
+	if g.recorder == nil {
+		return "", nil
 	}
+	return replay.SaveNew(g.recorder.Replay(g.State.Score))
 }
 
-func (g *Game) runGameLoop() {
-	ticker := time.NewTicker(g.State.Config.Speed)
-	defer ticker.Stop()
+func (g *Game) update() engine.Events {
+	// The engine checks the turn against the direction the snake actually
+	// moved last tick.
+	direction := g.turns.pop()
+	if g.recorder != nil {
+		g.recorder.Record(direction)
+	}
+	ev := g.Step(engine.Input{Direction: direction})
 
-	renderer := NewRenderer(g.State.Config)
-	renderer.Render(g)
+	if ev.FoodEaten && g.sound != nil {
+		go g.sound.PlayFoodEaten()
+	}
+	if ev.PowerUpCollected && g.sound != nil {
+		go g.sound.PlayPowerUpCollected()
+	}
 
-	for !g.State.ExitGame {
-		g.detectPause()
+	if ev.GameOver {
+		g.sound.PauseMusic()
+	}
+	return ev
+}
 
+func (g *Game) detectPause() {
+	for g.State.PauseGame && !g.State.ExitGame {
 		select {
 		case event := <-g.inputChan:
 			g.handleInput(event)
-			renderer.Render(g)
-		case <-ticker.C:
-			g.update()
-			if !g.State.ExitGame {
-				renderer.Render(g)
-			}
+		case <-g.resized:
+			g.handleResize()
 		}
 	}
+}
+
+func (g *Game) setPaused(paused bool) {
+	g.State.PauseGame = paused
+	if g.sound == nil {
+		return
+	}
+	if paused || !g.sound.enabled {
+		g.sound.PauseMusic()
+	} else {
+		g.sound.ResumeMusic()
+	}
+}
 
-	go g.sound.PlayGameOver()
+func (g *Game) toggleMute() {
+	g.sound.ToggleSound()
+	g.setPaused(g.State.PauseGame)
+}
 
-	fmt.Println("\nPress any key to continue...")
+// handleResize re-centers the board after the terminal changed size. The
+// board itself never changes size; if it no longer fits the game pauses
+// until the terminal is large enough again.
+func (g *Game) handleResize() {
+	config := g.State.Config
+	if !util.BoardFits(config.TermWidth, config.TermHeight) {
+		if !g.tooSmall {
+			g.tooSmall = true
+			g.setPaused(true)
+		}
+		renderTooSmall(g.theme, config, g.keymap.Describe(keymap.Quit))
+		return
+	}
+
+	g.tooSmall = false
+	config.OffsetX, config.OffsetY = util.CenterOffsets(config.TermWidth, config.TermHeight)
+	if r, ok := g.renderer.(interface{ Invalidate() }); ok {
+		r.Invalidate()
+	}
+	g.renderer.Render(g.Snapshot())
+}
 
-	g.writeHighScores()
+func renderTooSmall(t *theme.Theme, config *util.GameConfig, quitKeys string) {
+	width, height, _ := util.TerminalSize()
+	needWidth, needHeight := util.ScreenSize(config.TermWidth, config.TermHeight)
+	fmt.Print("\033[H\033[2J")
+	fmt.Print(t.Paint(t.Colors.Highlight, "Terminal too small") + "\r\n")
+	fmt.Printf("The %dx%d board needs %dx%d, the terminal is %dx%d.\r\n", config.TermWidth, config.TermHeight, needWidth, needHeight, width, height)
+	fmt.Printf("Enlarge the window to continue, or press %s to quit.\r\n", quitKeys)
 }
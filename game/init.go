@@ -7,8 +7,13 @@ package game
 
 import (
 	"fmt"
+	"gosnake/engine"
+	"gosnake/internal/keymap"
+	"gosnake/internal/replay"
+	"gosnake/internal/savegame"
+	"gosnake/internal/theme"
 	"gosnake/internal/util"
-	"os"
+	"math/rand/v2"
 
 	"github.com/eiannone/keyboard"
 )
@@ -17,79 +22,122 @@ func (g *Game) SetRelaxedMode(relaxed bool) {
 	g.State.RelaxedMode = relaxed
 }
 
-func (g *Game) InitSound(enableSound bool) {
-	g.sound = NewSoundManager(enableSound)
-
-	if enableSound {
-		go PlayMusic("./assets/background.mp3", -1) // Loop forever
-	}
+func (g *Game) SetRenderer(r Renderer) {
+	g.renderer = r
 }
 
-func (g *Game) Welcome() {
-	util.ClearScreen()
+func (g *Game) SetTheme(t *theme.Theme) {
+	g.theme = t
+}
 
-	fmt.Println("Welcome to GOSNAKE!")
-	fmt.Println()
-	fmt.Println("Controls: WASD/HJKL to move, 'p' to pause, 'q' to quit.")
-	fmt.Println()
-
-	fmt.Print("Game Mode: ")
-	switch g.State.Config.Mode {
-	case util.Normal:
-		fmt.Println("Normal - Classic Snake gameplay with increasing speed")
-	case util.NoWalls:
-		fmt.Println("No Walls - Snake can pass through borders")
-	case util.Maze:
-		fmt.Println("Maze - Navigate through randomly generated obstacles")
-	case util.PowerUps:
-		fmt.Println("Power-ups - Collect special items for unique abilities:")
-		fmt.Println("  ⚡ Speed Up   ⏳ Slow Down   👻 Ghost Mode")
-		fmt.Println("  🔄 Extra Length   💎 Double Points")
-	}
-	if g.State.RelaxedMode {
-		fmt.Println("Relaxed Mode: ON - Speed remains constant")
-	}
-	fmt.Println()
+// SetThemeLoader sets how the main menu loads the theme picked in it.
+func (g *Game) SetThemeLoader(load func(name string) (*theme.Theme, error)) {
+	g.loadTheme = load
+}
 
-	printHighScores()
-	fmt.Println()
-	fmt.Println("Press any key to start...")
+func (g *Game) SetKeymap(km keymap.Keymap) {
+	g.keymap = km
+}
 
-	char, key, _ := keyboard.GetKey()
+// SetQueueDepth sets how many turns pressed within one tick are kept.
+func (g *Game) SetQueueDepth(depth int) {
+	g.turns = newTurnQueue(depth)
+}
 
-	if char == 'q' || char == 'Q' || key == keyboard.KeyEsc {
-		os.Exit(0)
+func (g *Game) SetPlayerName(name string) {
+	if name != "" {
+		g.playerName = name
 	}
 }
 
+func (g *Game) InitSound(enableSound bool) {
+	g.sound = NewSoundManager(false)
+	g.sound.SetEnabled(enableSound)
+}
+
 func (g *Game) Start() {
-    if err := keyboard.Open(); err != nil {
+	if err := keyboard.Open(); err != nil {
 		fmt.Println("Error initializing keyboard input:", err)
 		return
 	}
 	defer keyboard.Close()
 
-	g.Welcome()
+	// The keyboard, the input goroutine and the music live as long as the
+	// session; each round only gets a new engine.
+	done := make(chan struct{})
+	defer close(done)
+	go g.pollInput(done)
+	defer StopMusic()
 
 	killSig()
 
+	if !g.resumed && !g.Welcome() {
+		util.ClearScreen()
+		return
+	}
+
 	util.ClearScreen()
 	util.HideCursor()
 	defer util.ShowCursor()
 
-	g.initializeGame()
-	g.runGameLoop()
+	base := *g.State.Config
+	if g.resumed {
+		base = g.start
+	}
+	for {
+		if g.resumed {
+			// The save is only removed once the round starts, so it
+			// survives a game that fails to start.
+			savegame.Remove()
+			g.resumed = false
+			g.setPaused(true)
+		} else {
+			g.start = *g.State.Config
+			g.recorder = replay.NewRecorder(g.State.Config, g.State.RelaxedMode)
+			g.Init()
+		}
+		g.runGameLoop()
+		if g.saveGame {
+			path, err := g.save()
+			util.ClearScreen()
+			if err != nil {
+				fmt.Println("Error saving game:", err)
+				return
+			}
+			fmt.Println("Game saved to", path)
+			fmt.Println(`Resume it with "gosnake play --resume".`)
+			return
+		}
+		if !g.restart {
+			choice, mode := g.gameOver()
+			if choice == quitGame {
+				util.ClearScreen()
+				return
+			}
+			if choice == changeMode {
+				base.Mode = mode
+			}
+		}
+		g.newRound(base)
+	}
 }
 
-func (g *Game) initializeGame() {
-	switch g.State.Config.Mode {
-	case util.Maze:
-		g.generateMaze()
-	case util.PowerUps:
-		g.spawnPowerUp()
+// newRound replaces the finished game with a fresh one on a new seed.
+func (g *Game) newRound(base util.GameConfig) {
+	config := base
+	config.Obstacles = nil
+	config.Seed = rand.Int64()
+	config.OffsetX, config.OffsetY = g.State.Config.OffsetX, g.State.Config.OffsetY
+	relaxed := g.State.RelaxedMode
+
+	g.Engine = engine.New(&config)
+	g.SetRelaxedMode(relaxed)
+	g.turns.clear()
+	g.restart = false
+	g.tooSmall = false
+	if r, ok := g.renderer.(interface{ Invalidate() }); ok {
+		r.Invalidate()
 	}
-
-	g.placeFood()
-	g.State.Board[g.State.Snake.Headx][g.State.Snake.Heady] = 1
-	go g.pollInput()
+	util.ClearScreen()
+	g.setPaused(false)
 }
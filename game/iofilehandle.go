@@ -7,113 +7,156 @@ package game
 
 import (
 	"fmt"
+	"gosnake/internal/highscore"
+	"gosnake/internal/keymap"
+	"gosnake/internal/theme"
 	"gosnake/internal/util"
-	"os"
-	"sort"
-	"strconv"
-	"strings"
+	"time"
 
 	"github.com/eiannone/keyboard"
 )
 
-func (g *Game) pollInput() {
-	for !g.State.ExitGame {
+// pollInput feeds key presses to inputChan for every round until done is
+// closed.
+func (g *Game) pollInput(done <-chan struct{}) {
+	for {
 		char, key, err := keyboard.GetKey()
 		if err != nil {
-			if g.State.ExitGame {
+			select {
+			case <-done:
 				return
+			default:
+				continue
 			}
-			continue
 		}
-		if !g.State.ExitGame {
-			g.inputChan <- keyboard.KeyEvent{Key: key, Rune: char}
+		select {
+		case g.inputChan <- keyboard.KeyEvent{Key: key, Rune: char}:
+		case <-done:
+			return
 		}
 	}
 }
 
-func (g *Game) handleInput(event keyboard.KeyEvent) {
-	switch {
-	case event.Rune == 'a' && g.State.Snake.Direction != 2 && !g.State.PauseGame:
-		g.State.Snake.Direction = util.DirectionLeft
-		g.State.Config.SnakeHead = "(:"
-	case event.Rune == 'd' && g.State.Snake.Direction != 4 && !g.State.PauseGame:
-		g.State.Snake.Direction = util.DirectionRight
-		g.State.Config.SnakeHead = ":)"
-	case event.Rune == 's' && g.State.Snake.Direction != 1 && !g.State.PauseGame:
-		g.State.Snake.Direction = util.DirectionDown
-		g.State.Config.SnakeHead = "()"
-	case event.Rune == 'w' && g.State.Snake.Direction != 3 && !g.State.PauseGame:
-		g.State.Snake.Direction = util.DirectionUp
-		g.State.Config.SnakeHead = "()"
+var directions = map[keymap.Action]int{
+	keymap.Up:    util.DirectionUp,
+	keymap.Down:  util.DirectionDown,
+	keymap.Left:  util.DirectionLeft,
+	keymap.Right: util.DirectionRight,
+}
 
-	case event.Rune == 'h' && g.State.Snake.Direction != 2 && !g.State.PauseGame:
-		g.State.Snake.Direction = util.DirectionLeft
-		g.State.Config.SnakeHead = "(:"
-	case event.Rune == 'l' && g.State.Snake.Direction != 4 && !g.State.PauseGame:
-		g.State.Snake.Direction = util.DirectionRight
-		g.State.Config.SnakeHead = ":)"
-	case event.Rune == 'j' && g.State.Snake.Direction != 1 && !g.State.PauseGame:
-		g.State.Snake.Direction = util.DirectionDown
-		g.State.Config.SnakeHead = "()"
-	case event.Rune == 'k' && g.State.Snake.Direction != 3 && !g.State.PauseGame:
-		g.State.Snake.Direction = util.DirectionUp
-		g.State.Config.SnakeHead = "()"
+func (g *Game) handleInput(event keyboard.KeyEvent) {
+	action, ok := g.keymap.Lookup(event)
+	if !ok {
+		return
+	}
 
-	case event.Rune == 'p' || event.Rune == 'P':
-		g.State.PauseGame = !g.State.PauseGame
-		if g.State.PauseGame {
-			if g.sound != nil {
-				g.sound.PauseMusic()
-			}
-		} else {
-			if g.sound != nil {
-				g.sound.ResumeMusic()
-			}
+	switch action {
+	case keymap.Up, keymap.Down, keymap.Left, keymap.Right:
+		if !g.State.PauseGame {
+			g.turns.push(directions[action], g.State.Snake.Direction)
 		}
-
-	case event.Key == keyboard.KeyEsc || event.Rune == 'q' || event.Rune == 'Q':
+	case keymap.Pause:
+		if !g.tooSmall {
+			g.setPaused(!g.State.PauseGame)
+		}
+	case keymap.Quit:
+		g.State.ExitGame = true
+	case keymap.Mute:
+		g.toggleMute()
+	case keymap.Restart:
+		g.restart = true
+		g.State.ExitGame = true
+	case keymap.Save:
+		g.saveGame = true
 		g.State.ExitGame = true
 	}
 }
 
-func readHighScores() []int {
-	file, _ := os.ReadFile("Score.txt")
-	lines := strings.Split(string(file), "\n")
-	scores := []int{}
-	for _, line := range lines {
-		if Score, err := strconv.Atoi(line); err == nil {
-			scores = append(scores, Score)
-		}
+func readHighScores() []highscore.Entry {
+	store, err := highscore.Open()
+	if err != nil {
+		fmt.Println("Warning:", err)
+	}
+	if store == nil {
+		return nil
+	}
+	scores, err := store.Load()
+	if err != nil {
+		fmt.Println("Warning: unable to read high scores:", err)
 	}
-	sort.Sort(sort.Reverse(sort.IntSlice(scores)))
 	return scores
 }
 
-func printHighScores() {
-	fmt.Println("Top Scores:")
-	scores := readHighScores()
-	for i, Score := range scores {
-		fmt.Printf("%d. %d\n", i+1, Score)
-		if i == 4 {
+func (g *Game) leaderboard() highscore.Board {
+	return highscore.BoardFor(g.State.Config.Mode, g.State.RelaxedMode, g.start.Speed, g.start.Duration)
+}
+
+// printHighScores prints the top 5 of board. If highlight is on the board it
+// is marked, and shown below the top 5 when it ranks lower.
+func printHighScores(t *theme.Theme, board highscore.Board, highlight *highscore.Entry, indent string) {
+	scores := highscore.Leaderboard(readHighScores(), board)
+	rank := 0
+	if highlight != nil {
+		rank = highscore.Rank(scores, *highlight)
+	}
+
+	fmt.Println(indent + "Top Scores (" + board.String() + "):")
+	if len(scores) == 0 {
+		fmt.Println(indent + "No scores yet.")
+	}
+	for i, score := range scores {
+		if i == 5 {
+			if rank > 5 {
+				fmt.Println(indent + "...")
+				printHighScore(t, indent, rank, scores[rank-1], true)
+			}
 			break
 		}
+		printHighScore(t, indent, i+1, score, i+1 == rank)
+	}
+}
+
+func printHighScore(t *theme.Theme, indent string, rank int, score highscore.Entry, highlight bool) {
+	line := fmt.Sprintf("%d. %-16s %d", rank, score.Player, score.Score)
+	if score.Won {
+		line += " (won)"
+	}
+	if highlight {
+		line = t.Paint(t.Colors.Highlight, line+"  <- you")
 	}
+	fmt.Println(indent + line)
 }
 
-func (g *Game) writeHighScores() {
+// writeHighScores saves the finished game and returns its entry, or nil if
+// nothing was saved.
+func (g *Game) writeHighScores() (*highscore.Entry, error) {
 	if g.State.Score == 0 {
-		return
+		return nil, nil
 	}
 
-	file, err := os.OpenFile("Score.txt", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		fmt.Println("Error saving high Score:", err)
-		return
+	store, err := highscore.Open()
+	if store == nil {
+		return nil, err
 	}
-	defer file.Close()
 
-	_, err = file.WriteString(fmt.Sprintf("%d\n", g.State.Score))
-	if err != nil {
-		fmt.Println("Error writing high Score:", err)
+	entry := highscore.Entry{
+		Player:     g.playerName,
+		Score:      g.State.Score,
+		Mode:       g.State.Config.Mode,
+		Relaxed:    g.State.RelaxedMode,
+		SpeedMS:    int(g.start.Speed / time.Millisecond),
+		Width:      g.State.Config.TermWidth,
+		Height:     g.State.Config.TermHeight,
+		Length:     g.State.Snake.Length,
+		DurationMS: g.State.Clock.Milliseconds(),
+		Time:       time.Now(),
+		Won:        g.State.ExitCode == util.Victory,
+	}
+	if entry.Mode == util.TimeAttack {
+		entry.BudgetS = int(g.start.Duration / time.Second)
+	}
+	if err := store.Add(entry); err != nil {
+		return nil, err
 	}
+	return &entry, nil
 }
@@ -0,0 +1,312 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"gosnake/engine"
+	"gosnake/internal/highscore"
+	"gosnake/internal/keymap"
+	"gosnake/internal/theme"
+	"gosnake/internal/util"
+
+	"github.com/eiannone/keyboard"
+)
+
+const (
+	minMenuSpeed  = 20 * time.Millisecond
+	maxMenuSpeed  = 1000 * time.Millisecond
+	menuSpeedStep = 10 * time.Millisecond
+)
+
+// boardSize is a board size the menu offers. Zero follows the terminal.
+type boardSize struct {
+	label         string
+	width, height int
+}
+
+var boardPresets = []boardSize{
+	{"auto", 0, 0},
+	{"small", 20, 10},
+	{"medium", 40, 20},
+	{"large", 60, 30},
+}
+
+type menuResult int
+
+const (
+	menuStay menuResult = iota
+	menuStart
+	menuQuit
+)
+
+// mainMenu is the Welcome screen. It starts out with the settings the game
+// was configured with, from the config file and flags.
+type mainMenu struct {
+	game    *Game
+	menu    menu
+	rows    []menuRow
+	themes  []string
+	theme   int
+	custom  *theme.Theme // loaded from a path, first in themes
+	sizes   []boardSize
+	size    int
+	message string
+	scores  bool // showing the leaderboards
+}
+
+// menuRow is either a setting, changed with left and right, or an action.
+type menuRow struct {
+	label  string
+	value  func() string
+	change func(delta int)
+	enter  func() menuResult
+}
+
+func newMainMenu(g *Game) *mainMenu {
+	m := &mainMenu{game: g, themes: theme.Names()}
+
+	m.theme = -1
+	for i, name := range m.themes {
+		if name == g.theme.Name {
+			m.theme = i
+		}
+	}
+	if m.theme < 0 {
+		// A theme loaded from a path is kept as it is, since its name
+		// alone does not say where it came from.
+		m.themes = append([]string{g.theme.Name}, m.themes...)
+		m.theme = 0
+		m.custom = g.theme
+	}
+
+	config := g.State.Config
+	m.sizes = []boardSize{boardPresets[0]}
+	if width, height := util.GetBoardSize(); config.TermWidth != width || config.TermHeight != height {
+		m.sizes = append(m.sizes, boardSize{"custom", config.TermWidth, config.TermHeight})
+		m.size = 1
+	}
+	m.sizes = append(m.sizes, boardPresets[1:]...)
+
+	m.rows = []menuRow{
+		{label: "Start game", enter: func() menuResult { return m.start() }},
+		{label: "Mode", value: func() string { return config.Mode.String() }, change: m.changeMode},
+		{label: "Relaxed", value: func() string { return onOff(g.State.RelaxedMode) }, change: func(int) {
+			g.SetRelaxedMode(!g.State.RelaxedMode)
+		}},
+		{label: "Speed", value: func() string { return fmt.Sprint(config.Speed) }, change: func(delta int) {
+			config.Speed = min(max(config.Speed+time.Duration(delta)*menuSpeedStep, minMenuSpeed), maxMenuSpeed)
+		}},
+		{label: "Theme", value: func() string { return m.themes[m.theme] }, change: m.changeTheme},
+		{label: "Sound", value: func() string { return onOff(g.sound.enabled) }, change: func(int) {
+			g.toggleMute()
+		}},
+		{label: "Board", value: m.sizeLabel, change: m.changeSize},
+		{label: "Leaderboards", enter: func() menuResult {
+			m.scores = true
+			return menuStay
+		}},
+		{label: "Quit", enter: func() menuResult { return menuQuit }},
+	}
+	return m
+}
+
+// Welcome shows the main menu and reports whether to start a game.
+func (g *Game) Welcome() bool {
+	m := newMainMenu(g)
+
+	resized, stopResize := watchResize()
+	defer stopResize()
+
+	for {
+		m.draw()
+		select {
+		case <-resized:
+		case event := <-g.inputChan:
+			switch m.handleInput(event) {
+			case menuStart:
+				return true
+			case menuQuit:
+				return false
+			}
+		}
+	}
+}
+
+func (m *mainMenu) handleInput(event keyboard.KeyEvent) menuResult {
+	if m.scores {
+		m.scores = false
+		return menuStay
+	}
+	m.message = ""
+
+	row := m.rows[m.menu.selected]
+	action, bound := m.game.keymap.Lookup(event)
+	switch {
+	case bound && action == keymap.Up:
+		m.menu.move(-1)
+	case bound && action == keymap.Down:
+		m.menu.move(1)
+	case bound && action == keymap.Left && row.change != nil:
+		row.change(-1)
+	case bound && action == keymap.Right && row.change != nil:
+		row.change(1)
+	case bound && action == keymap.Quit:
+		return menuQuit
+	case event.Key == keyboard.KeyEnter || event.Key == keyboard.KeySpace:
+		if row.enter != nil {
+			return row.enter()
+		}
+		row.change(1)
+	}
+	return menuStay
+}
+
+func (m *mainMenu) changeMode(delta int) {
+	modes := util.GameModes()
+	config := m.game.State.Config
+	for i, mode := range modes {
+		if mode == config.Mode {
+			config.Mode = modes[(i+delta+len(modes))%len(modes)]
+			return
+		}
+	}
+}
+
+func (m *mainMenu) changeTheme(delta int) {
+	g := m.game
+	next := (m.theme + delta + len(m.themes)) % len(m.themes)
+	t := m.custom
+	if next != 0 || t == nil {
+		var err error
+		if t, err = g.loadTheme(m.themes[next]); err != nil {
+			m.message = err.Error()
+			return
+		}
+	}
+	g.theme = t
+	m.theme = next
+}
+
+// changeSize moves to the next board size that fits the terminal.
+func (m *mainMenu) changeSize(delta int) {
+	for i := 1; i <= len(m.sizes); i++ {
+		next := (m.size + delta*i + len(m.sizes)*i) % len(m.sizes)
+		size := m.sizes[next]
+		if size.width == 0 || util.BoardFits(size.width, size.height) {
+			m.size = next
+			return
+		}
+	}
+}
+
+func (m *mainMenu) sizeLabel() string {
+	size := m.sizes[m.size]
+	if size.width == 0 {
+		size.width, size.height = util.GetBoardSize()
+	}
+	return fmt.Sprintf("%s (%dx%d)", size.label, size.width, size.height)
+}
+
+// start applies the board size and sets up a new engine for it.
+func (m *mainMenu) start() menuResult {
+	g := m.game
+	config := g.State.Config
+	size := m.sizes[m.size]
+	if size.width == 0 {
+		config.TermWidth, config.TermHeight = util.GetBoardSize()
+	} else if err := config.SetBoardSize(size.width, size.height); err != nil {
+		m.message = err.Error()
+		return menuStay
+	}
+	config.OffsetX, config.OffsetY = util.CenterOffsets(config.TermWidth, config.TermHeight)
+
+	relaxed := g.State.RelaxedMode
+	g.Engine = engine.New(config)
+	g.SetRelaxedMode(relaxed)
+	return menuStart
+}
+
+func (m *mainMenu) draw() {
+	g := m.game
+	t := g.theme
+	config := g.State.Config
+	// Every key press redraws, so clear without running clear.
+	fmt.Print("\033[H\033[2J")
+
+	if m.scores {
+		for _, mode := range util.GameModes() {
+			printHighScores(t, highscore.BoardFor(mode, g.State.RelaxedMode, config.Speed, config.Duration), nil, "")
+			fmt.Println()
+		}
+		fmt.Println("Press any key to go back.")
+		return
+	}
+
+	fmt.Println("Welcome to GOSNAKE!")
+	fmt.Println()
+	m.menu.items = m.menu.items[:0]
+	for _, row := range m.rows {
+		item := menuItem{label: row.label}
+		if row.value != nil {
+			item.label = fmt.Sprintf("%-12s < %s >", row.label, row.value())
+		}
+		m.menu.items = append(m.menu.items, item)
+	}
+	m.menu.items[len(m.menu.items)-1].hint = g.keymap.Describe(keymap.Quit)
+	m.menu.draw(t, "  ")
+	fmt.Println()
+	if m.message != "" {
+		fmt.Println(t.Paint(t.Colors.Highlight, m.message))
+		fmt.Println()
+	}
+
+	g.describeMode()
+	fmt.Println("Seed:", config.Seed)
+	fmt.Println()
+
+	km := g.keymap
+	fmt.Println("Controls:")
+	fmt.Printf("  Move: %s, %s, %s, %s\n", km.Describe(keymap.Up), km.Describe(keymap.Down), km.Describe(keymap.Left), km.Describe(keymap.Right))
+	fmt.Printf("  Pause: %s   Quit: %s   Mute: %s   Restart: %s   Save: %s\n", km.Describe(keymap.Pause), km.Describe(keymap.Quit), km.Describe(keymap.Mute), km.Describe(keymap.Restart), km.Describe(keymap.Save))
+	fmt.Println("  In this menu: up/down to choose, left/right to change, Enter to select.")
+	fmt.Println()
+
+	printHighScores(t, highscore.BoardFor(config.Mode, g.State.RelaxedMode, config.Speed, config.Duration), nil, "")
+}
+
+func (g *Game) describeMode() {
+	fmt.Print("Game Mode: ")
+	switch g.State.Config.Mode {
+	case util.Normal:
+		fmt.Println("Normal - Classic Snake gameplay with increasing speed")
+	case util.NoWalls:
+		fmt.Println("No Walls - Snake can pass through borders")
+	case util.Maze:
+		fmt.Println("Maze - Navigate through randomly generated obstacles")
+	case util.PowerUps:
+		fmt.Println("Power-ups - Collect special items for unique abilities:")
+		glyphs := g.theme.Glyphs
+		fmt.Printf("  %s Speed Up   %s Slow Down   %s Ghost Mode\n", glyphs.SpeedUp, glyphs.SlowDown, glyphs.Ghost)
+		fmt.Printf("  %s Extra Length   %s Double Points\n", glyphs.ExtraLength, glyphs.DoublePoints)
+	case util.TimeAttack:
+		fmt.Printf("Time Attack - Eat as much as you can in %s, each food adds %s\n", g.State.Config.Duration, util.TimeAttackBonus)
+	case util.Survival:
+		fmt.Printf("Survival - The walls close in every %s, score a point per second and per food\n", util.ShrinkInterval)
+	}
+	if g.State.RelaxedMode {
+		fmt.Println("Relaxed Mode: ON - Speed remains constant")
+	}
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package game
+
+import (
+	"fmt"
+
+	"gosnake/internal/theme"
+)
+
+// menu is a vertical list of choices with one selected.
+type menu struct {
+	items    []menuItem
+	selected int
+}
+
+type menuItem struct {
+	label string
+	hint  string // keys that pick the item directly
+}
+
+func (m *menu) move(delta int) {
+	if len(m.items) == 0 {
+		return
+	}
+	m.selected = (m.selected + delta + len(m.items)) % len(m.items)
+}
+
+func (m *menu) draw(t *theme.Theme, indent string) {
+	for i, item := range m.items {
+		line := item.label
+		if item.hint != "" {
+			line += " (" + item.hint + ")"
+		}
+		if i == m.selected {
+			fmt.Println(indent + t.Paint(t.Colors.Highlight, "> "+line))
+		} else {
+			fmt.Println(indent + "  " + line)
+		}
+	}
+}
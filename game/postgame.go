@@ -0,0 +1,236 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"gosnake/internal/highscore"
+	"gosnake/internal/keymap"
+	"gosnake/internal/util"
+
+	"github.com/eiannone/keyboard"
+)
+
+const maxNameLength = 16
+
+// Keys pressed right as the snake crashes were meant for the game, not for
+// the game over screen.
+const gameOverGrace = 400 * time.Millisecond
+
+type postGameChoice int
+
+const (
+	playAgain postGameChoice = iota
+	changeMode
+	quitGame
+)
+
+type postGameStep int
+
+const (
+	enterName postGameStep = iota
+	chooseNext
+	chooseMode
+)
+
+// postGame is the game over screen: final stats, the name to save the
+// score under, then what to play next.
+type postGame struct {
+	game     *Game
+	step     postGameStep
+	name     []rune
+	entry    *highscore.Entry
+	message  string
+	next     menu
+	modes    menu
+	duration time.Duration
+}
+
+// gameOver shows the game over screen and returns what the player picked,
+// with the mode to play when that is changeMode.
+func (g *Game) gameOver() (postGameChoice, util.GameMode) {
+	go g.sound.PlayGameOver()
+
+	p := &postGame{
+		game:     g,
+		name:     []rune(g.playerName),
+		duration: g.State.Clock,
+		next: menu{items: []menuItem{
+			{label: "Play again", hint: g.keymap.Describe(keymap.Restart)},
+			{label: "Change mode", hint: "C"},
+			{label: "Quit", hint: g.keymap.Describe(keymap.Quit)},
+		}},
+	}
+	for _, mode := range util.GameModes() {
+		p.modes.items = append(p.modes.items, menuItem{label: mode.String()})
+		if mode == g.State.Config.Mode {
+			p.modes.selected = len(p.modes.items) - 1
+		}
+	}
+	if path, err := g.saveReplay(); err != nil {
+		p.message = "Error saving replay: " + err.Error()
+	} else if path != "" {
+		p.message = "Replay saved to " + path
+	}
+	if g.State.Score == 0 {
+		p.step = chooseNext
+	}
+
+	resized, stopResize := watchResize()
+	defer stopResize()
+
+	for {
+		p.draw()
+		select {
+		case <-resized:
+		case event := <-g.inputChan:
+			if time.Since(g.endTime) < gameOverGrace {
+				continue
+			}
+			if choice, done := p.handleInput(event); done {
+				return choice, util.GameModes()[p.modes.selected]
+			}
+		}
+	}
+}
+
+func (p *postGame) handleInput(event keyboard.KeyEvent) (postGameChoice, bool) {
+	g := p.game
+	action, bound := g.keymap.Lookup(event)
+	switch p.step {
+	case enterName:
+		switch {
+		case event.Key == keyboard.KeyEnter:
+			p.save()
+		case event.Key == keyboard.KeyEsc:
+			p.step = chooseNext
+		case event.Key == keyboard.KeyBackspace || event.Key == keyboard.KeyBackspace2:
+			if len(p.name) > 0 {
+				p.name = p.name[:len(p.name)-1]
+			}
+		case event.Key == keyboard.KeySpace:
+			p.typeRune(' ')
+		case event.Key == 0 && unicode.IsPrint(event.Rune):
+			p.typeRune(event.Rune)
+		}
+
+	case chooseNext:
+		switch {
+		case bound && action == keymap.Up:
+			p.next.move(-1)
+		case bound && action == keymap.Down:
+			p.next.move(1)
+		case bound && action == keymap.Restart:
+			return playAgain, true
+		case bound && action == keymap.Quit:
+			return quitGame, true
+		case event.Rune == 'c' || event.Rune == 'C':
+			p.step = chooseMode
+		case event.Key == keyboard.KeyEnter || event.Key == keyboard.KeySpace:
+			if choice := postGameChoice(p.next.selected); choice != changeMode {
+				return choice, true
+			}
+			p.step = chooseMode
+		}
+
+	case chooseMode:
+		switch {
+		case bound && action == keymap.Up:
+			p.modes.move(-1)
+		case bound && action == keymap.Down:
+			p.modes.move(1)
+		case bound && action == keymap.Quit:
+			p.step = chooseNext
+		case event.Key == keyboard.KeyEnter || event.Key == keyboard.KeySpace:
+			return changeMode, true
+		}
+	}
+	return 0, false
+}
+
+func (p *postGame) typeRune(r rune) {
+	if len(p.name) < maxNameLength {
+		p.name = append(p.name, r)
+	}
+}
+
+func (p *postGame) save() {
+	g := p.game
+	g.SetPlayerName(strings.TrimSpace(string(p.name)))
+	p.name = []rune(g.playerName)
+
+	entry, err := g.writeHighScores()
+	if err != nil {
+		p.message = "Error saving high score: " + err.Error()
+	}
+	p.entry = entry
+	p.step = chooseNext
+}
+
+func (p *postGame) draw() {
+	g := p.game
+	t := g.theme
+
+	fmt.Print("\033[H\033[2J")
+	title := "GAME OVER"
+	if g.State.ExitCode == util.Victory {
+		title = "YOU WIN"
+	}
+	fmt.Println(t.Paint(t.Colors.Highlight, title) + " - " + gameOverReason(g.State.ExitCode))
+	fmt.Println()
+	fmt.Printf("Score: %d   Length: %d   Time: %s\n", g.State.Score, g.State.Snake.Length, p.duration.Round(time.Second))
+	fmt.Println("Board:", g.leaderboard())
+	fmt.Println()
+	if p.message != "" {
+		fmt.Println(p.message)
+		fmt.Println()
+	}
+
+	switch p.step {
+	case enterName:
+		fmt.Printf("Name for the high scores: %s_\n", string(p.name))
+		fmt.Println("Enter to save, Esc to skip.")
+		return
+	case chooseMode:
+		fmt.Println("Game mode:")
+		p.modes.draw(t, "  ")
+		fmt.Println()
+		fmt.Println("Enter to play, " + g.keymap.Describe(keymap.Quit) + " to go back.")
+		return
+	}
+
+	if p.entry != nil {
+		scores := highscore.Leaderboard(readHighScores(), g.leaderboard())
+		rank := highscore.Rank(scores, *p.entry)
+		if rank == 1 {
+			fmt.Println(t.Paint(t.Colors.Highlight, "New high score!"))
+		} else if rank > 0 {
+			fmt.Printf("Your score ranks #%d of %d.\n", rank, len(scores))
+		}
+		fmt.Println()
+	}
+	printHighScores(t, g.leaderboard(), p.entry, "")
+	fmt.Println()
+	p.next.draw(t, "")
+}
+
+func gameOverReason(exitCode int) string {
+	switch exitCode {
+	case util.CollisionWall:
+		return "the snake hit a wall"
+	case util.CollisionSelf:
+		return "the snake ran into itself"
+	case util.Victory:
+		return "the snake filled the board"
+	case util.TimeUp:
+		return "time ran out"
+	}
+	return "game ended"
+}
@@ -7,176 +7,384 @@ package game
 
 import (
 	"fmt"
+	"gosnake/engine"
+	"gosnake/internal/theme"
 	"gosnake/internal/util"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 	"time"
 )
 
-type Renderer struct {
-	Config *util.GameConfig
+// Renderer draws frames of a game. Implementations only get a snapshot, so
+// they can never change the game they are drawing.
+type Renderer interface {
+	Render(s *engine.Snapshot)
 }
 
-func NewRenderer(Config *util.GameConfig) *Renderer {
-	return &Renderer{Config: Config}
+// ANSIRenderer draws the colored, centered board the terminal game uses.
+// It remembers the last frame and only rewrites the cells that changed.
+type ANSIRenderer struct {
+	out   io.Writer
+	theme *theme.Theme
+
+	// FullRedraw disables diffing and rewrites every cell on every frame.
+	FullRedraw bool
+	// Footer is an extra line drawn below the game, e.g. replay controls.
+	Footer string
+	// PauseKey is shown in the pause indicator, P if empty.
+	PauseKey string
+
+	prev       map[screenPos]string
+	prevLayout layout
+
+	// trail holds the cells the tail left in the last few moves, newest
+	// first. It is only drawn in 256-color and truecolor terminals.
+	trail     [][]boardPos
+	lastHead  boardPos
+	lastBoard util.Board
 }
 
-func (r *Renderer) Render(g *Game) {
-	var builder strings.Builder
+type boardPos struct {
+	x, y int
+}
 
-	r.renderTopOffset(&builder)
-	r.renderTopAndBottomBorder(&builder, g.PowerMgr.GhostMode)
-	r.renderBoard(&builder, g)
-	r.renderTopAndBottomBorder(&builder, g.PowerMgr.GhostMode)
-	r.renderScore(&builder, g.State.Score)
-	if g.State.PauseGame {
-		r.renderPauseIndicator(&builder, g)
-	} else if g.State.Config.Mode == util.PowerUps {
-		r.renderActiveEffects(&builder, g)
-	} else {
-		r.renderEmptyLine(&builder, g)
-	}
+const trailLength = 3
 
-	util.GoAtTopLeft()
-	fmt.Print(builder.String())
+type screenPos struct {
+	row, col int
 }
 
-func (r *Renderer) decideColor(builder *strings.Builder, isGhostMode bool) {
-	builder.WriteString(util.BLACK)
+type screenCell struct {
+	pos  screenPos
+	text string
+}
 
-	if isGhostMode {
-		builder.WriteString(util.GREEN)
-		return
+type layout struct {
+	offsetX, offsetY, width, height int
+}
+
+func NewANSIRenderer(out io.Writer, t *theme.Theme) *ANSIRenderer {
+	return &ANSIRenderer{out: out, theme: t}
+}
+
+func NewRenderer(t *theme.Theme) *ANSIRenderer {
+	return NewANSIRenderer(os.Stdout, t)
+}
+
+// Invalidate forgets the previous frame so the next one is drawn in full.
+func (r *ANSIRenderer) Invalidate() {
+	r.prev = nil
+	r.trail = nil
+	r.lastBoard = nil
+}
+
+func (r *ANSIRenderer) Render(s *engine.Snapshot) {
+	r.updateTrail(s)
+	cells, lastRow := r.frame(s)
+	l := layout{s.Config.OffsetX, s.Config.OffsetY, s.Config.TermWidth, s.Config.TermHeight}
+
+	var builder strings.Builder
+	clear := r.prev == nil || l != r.prevLayout
+	if clear {
+		builder.WriteString("\033[H\033[2J")
+		r.prev = make(map[screenPos]string, len(cells))
+		r.prevLayout = l
 	}
+	full := clear || r.FullRedraw
+	for _, c := range cells {
+		if !full && r.prev[c.pos] == c.text {
+			continue
+		}
+		builder.WriteString(util.CursorTo(c.pos.row, c.pos.col))
+		builder.WriteString(c.text)
+		r.prev[c.pos] = c.text
+	}
+	// Leave the cursor below the game so anything printed afterwards
+	// does not land on the board.
+	builder.WriteString(util.CursorTo(lastRow+1, 1))
 
-	switch r.Config.Mode {
-	case util.NoWalls:
-		builder.WriteString(util.GREEN)
-	default:
-		builder.WriteString(util.RED)
-	}
-}
-
-func (r *Renderer) renderBoard(builder *strings.Builder, g *Game) {
-	for x := 0; x < g.State.Config.TermHeight; x++ {
-		r.decideColor(builder, g.PowerMgr.GhostMode)
-		builder.WriteString(strings.Repeat(" ", g.State.Config.OffsetX-1) + g.State.Config.BorderChar)
-		builder.WriteString(util.BLACK)
-
-		for y := 0; y < g.State.Config.TermWidth; y++ {
-			switch {
-			case g.State.Board[x][y] == 0:
-				builder.WriteString(g.State.Config.EmptyCell)
-			case g.State.Board[x][y] == -1:
-				builder.WriteString(g.State.Config.FoodCell)
-			case g.State.Board[x][y] == 999:
-				builder.WriteString(util.RED + g.State.Config.MazeChar + util.BLACK)
-			case g.State.Board[x][y] < -1:
-				switch util.PowerUpType(g.State.Board[x][y]) {
-				case util.SpeedUp:
-					builder.WriteString("⚡")
-				case util.SlowDown:
-					builder.WriteString("⏳")
-				case util.GhostMode:
-					builder.WriteString("👻")
-				case util.ExtraLength:
-					builder.WriteString("🔄")
-				case util.DoublePoints:
-					builder.WriteString("💎")
-				}
-			case g.State.Board[x][y] > 0:
-				for _, powerup := range g.PowerMgr.ActivePowerUps {
-					switch powerup.Type {
-					case util.SpeedUp:
-						builder.WriteString(util.YELLOW)
-					case util.SlowDown:
-						builder.WriteString(util.BLUE)
-					}
-				}
-				if g.State.Board[x][y] == 1 {
-					builder.WriteString(g.State.Config.SnakeHead)
-				} else {
-					builder.WriteString(g.State.Config.SnakeCell)
+	io.WriteString(r.out, builder.String())
+}
+
+// updateTrail remembers the cells the tail left each time the head moves.
+func (r *ANSIRenderer) updateTrail(s *engine.Snapshot) {
+	if r.theme.Glyphs.Trail == "" || !r.theme.Gradients() {
+		r.trail = nil
+		return
+	}
+	head := boardPos{s.Snake.Headx, s.Snake.Heady}
+	if len(r.lastBoard) == len(s.Board) && head != r.lastHead {
+		var left []boardPos
+		for x, row := range s.Board {
+			for y, cell := range row {
+				if !cell.Occupied() && y < len(r.lastBoard[x]) && r.lastBoard[x][y].Occupied() {
+					left = append(left, boardPos{x, y})
 				}
-				builder.WriteString(util.BLACK)
 			}
 		}
-		r.decideColor(builder, g.PowerMgr.GhostMode)
-		builder.WriteString(g.State.Config.BorderChar + "\n")
-		builder.WriteString(util.BLACK)
+		r.trail = append([][]boardPos{left}, r.trail...)
+		if len(r.trail) > trailLength {
+			r.trail = r.trail[:trailLength]
+		}
+	}
+	r.lastHead = head
+	r.lastBoard = s.Board
+}
+
+// trailAge returns how many moves ago the tail left x, y, or -1.
+func (r *ANSIRenderer) trailAge(x, y int) int {
+	for age, cells := range r.trail {
+		for _, pos := range cells {
+			if pos == (boardPos{x, y}) {
+				return age
+			}
+		}
+	}
+	return -1
+}
+
+// frame lays out every cell of s on screen and returns them with the last
+// row used.
+func (r *ANSIRenderer) frame(s *engine.Snapshot) ([]screenCell, int) {
+	cells := make([]screenCell, 0, (s.Config.TermWidth+2)*s.Config.TermHeight+6)
+	left := s.Config.OffsetX
+	row := max(s.Config.OffsetY-2, 0) + 1
+
+	cells = append(cells, screenCell{screenPos{row, left}, r.border(s, 2*(s.Config.TermWidth+1))})
+	for x := 0; x < s.Config.TermHeight; x++ {
+		row++
+		cells = append(cells, screenCell{screenPos{row, left}, r.border(s, 1)})
+		for y := 0; y < s.Config.TermWidth; y++ {
+			cells = append(cells, screenCell{screenPos{row, left + 1 + 2*y}, r.cell(s, x, y)})
+		}
+		cells = append(cells, screenCell{screenPos{row, left + 1 + 2*s.Config.TermWidth}, r.border(s, 1)})
 	}
+	row++
+	cells = append(cells, screenCell{screenPos{row, left}, r.border(s, 2*(s.Config.TermWidth+1))})
+
+	row += 2
+	cells = append(cells, screenCell{screenPos{row, left}, "Score: " + strconv.Itoa(s.Score) + "\033[K"})
+
+	row++
+	status := ""
+	if s.Paused {
+		pause, key := r.theme.Glyphs.Pause, r.PauseKey
+		if key == "" {
+			key = "P"
+		}
+		status = r.theme.Paint(r.theme.Colors.Highlight, pause+" PAUSED - Press "+key+" to Resume "+pause)
+	} else if s.Config.Mode == util.PowerUps {
+		status = activeEffects(r.theme, s)
+	} else if s.Config.Mode == util.TimeAttack {
+		status = timeLeft(r.theme, s)
+	} else if s.Config.Mode == util.Survival {
+		status = arenaTimer(r.theme, s)
+	}
+	cells = append(cells, screenCell{screenPos{row, left}, status + "\033[K"})
+
+	if r.Footer != "" {
+		row += 2
+		cells = append(cells, screenCell{screenPos{row, left}, r.Footer + "\033[K"})
+	}
+	return cells, row
 }
 
-func (r *Renderer) renderTopAndBottomBorder(builder *strings.Builder, isGhostMode bool) {
-	r.decideColor(builder, isGhostMode)
-	builder.WriteString(strings.Repeat(" ", r.Config.OffsetX-1))
-	builder.WriteString(strings.Repeat(r.Config.BorderChar, 2*(r.Config.TermWidth+1)))
-	builder.WriteString(util.BLACK)
-	builder.WriteString("\n")
+func (r *ANSIRenderer) borderColor(s *engine.Snapshot) string {
+	if s.GhostMode || s.Config.Mode == util.NoWalls {
+		return r.theme.Colors.BorderOpen
+	}
+	return r.theme.Colors.Border
 }
 
-func (r *Renderer) renderTopOffset(builder *strings.Builder) {
-	for x := 0; x < r.Config.OffsetY-2; x++ {
-		builder.WriteString("\n")
+func (r *ANSIRenderer) border(s *engine.Snapshot, width int) string {
+	return r.theme.Paint(r.borderColor(s), strings.Repeat(frameGlyph(r.theme, s), width))
+}
+
+// frameGlyph is the glyph around the board. Once a survival arena closes
+// in, the border is drawn around the live area instead.
+func frameGlyph(t *theme.Theme, s *engine.Snapshot) string {
+	if s.Inset > 0 {
+		return " "
 	}
+	return t.Glyphs.Border
 }
 
-func (r *Renderer) renderScore(builder *strings.Builder, score int) {
-	builder.WriteString("\n" + strings.Repeat(" ", r.Config.OffsetX-1) + "Score: " + strconv.Itoa(score) + "\n")
+func (r *ANSIRenderer) cell(s *engine.Snapshot, x, y int) string {
+	colors := r.theme.Colors
+	switch cell := s.Board[x][y]; {
+	case cell.Occupied():
+		color := colors.Snake
+		if r.theme.Gradients() && colors.SnakeTail != "" && s.Snake.Length > 1 {
+			color = theme.Blend(colors.Snake, colors.SnakeTail, float64(s.Snake.Age(cell.Occupant)-1)/float64(s.Snake.Length-1))
+		}
+		for _, powerup := range s.ActivePowerUps {
+			switch powerup.Type {
+			case util.SpeedUp:
+				color = colors.SpeedUp
+			case util.SlowDown:
+				color = colors.SlowDown
+			}
+		}
+		return r.theme.Paint(color, cellGlyph(r.theme, s, x, y))
+	case cell.Item.Kind == util.ItemFood:
+		return r.theme.Paint(colors.Food, cellGlyph(r.theme, s, x, y))
+	case cell.Item.Kind == util.ItemPowerUp:
+		return r.theme.Paint(colors.PowerUp, cellGlyph(r.theme, s, x, y))
+	case cell.Terrain == util.TerrainWall:
+		return r.theme.Paint(colors.Maze, cellGlyph(r.theme, s, x, y))
+	case cell.Terrain == util.TerrainClosed:
+		return r.theme.Paint(r.borderColor(s), cellGlyph(r.theme, s, x, y))
+	case r.trail != nil:
+		age := r.trailAge(x, y)
+		if age < 0 {
+			return cellGlyph(r.theme, s, x, y)
+		}
+		tail := colors.SnakeTail
+		if tail == "" {
+			tail = colors.Snake
+		}
+		dim := theme.Blend(tail, "black", 0.4+0.2*float64(age))
+		return r.theme.Paint(dim, r.theme.Cell(r.theme.Glyphs.Trail))
+	default:
+		return cellGlyph(r.theme, s, x, y)
+	}
 }
 
-func (r *Renderer) renderPauseIndicator(builder *strings.Builder, g *Game) {
-	builder.WriteString(strings.Repeat(" ", r.Config.OffsetX-1))
-	builder.WriteString(util.YELLOW + "⏸ PAUSED - Press P to Resume ⏸" + util.BLACK)
-	builder.WriteString(strings.Repeat(" ", g.State.Config.TermWidth-10))
-	builder.WriteString("\n")
+// cellGlyph is what every renderer draws for a board cell, padded to the
+// cell's two columns. A snake is drawn over items, items over terrain.
+func cellGlyph(t *theme.Theme, s *engine.Snapshot, x, y int) string {
+	switch cell := s.Board[x][y]; {
+	case cell.Occupied() && s.Snake.Age(cell.Occupant) == 1:
+		return t.Cell(headGlyph(t, s))
+	case cell.Occupied():
+		return t.Cell(t.Glyphs.SnakeBody)
+	case cell.Item.Kind == util.ItemFood:
+		return t.Cell(t.Glyphs.Food)
+	case cell.Item.Kind == util.ItemPowerUp:
+		symbol, _ := powerUpInfo(t, cell.Item.PowerUp)
+		return t.Cell(symbol)
+	case cell.Terrain == util.TerrainWall:
+		return t.Wall()
+	case cell.Terrain == util.TerrainClosed:
+		// The innermost closed ring is the arena's border.
+		ring := min(x, y, s.Config.TermHeight-1-x, s.Config.TermWidth-1-y)
+		if ring == s.Inset-1 {
+			return strings.Repeat(t.Glyphs.Border, 2)
+		}
+		return "  "
+	default:
+		return t.Empty()
+	}
 }
 
-func (r *Renderer) renderEmptyLine(builder *strings.Builder, g *Game) {
-	builder.WriteString(strings.Repeat(" ", r.Config.OffsetX-1))
-	builder.WriteString(strings.Repeat(" ", g.State.Config.TermWidth))
-	builder.WriteString("\n")
+// headGlyph draws the head facing the way the snake moves: SnakeHead faces
+// right and its mirror image faces left. Themes without up and down heads
+// draw the head like the body when moving vertically.
+func headGlyph(t *theme.Theme, s *engine.Snapshot) string {
+	glyph := ""
+	switch s.Snake.Direction {
+	case util.DirectionRight:
+		glyph = t.Glyphs.SnakeHead
+	case util.DirectionLeft:
+		glyph = mirror(t.Glyphs.SnakeHead)
+	case util.DirectionUp:
+		glyph = t.Glyphs.SnakeHeadUp
+	case util.DirectionDown:
+		glyph = t.Glyphs.SnakeHeadDown
+	}
+	if glyph == "" {
+		glyph = t.Glyphs.SnakeBody
+	}
+	return glyph
 }
 
-func (r *Renderer) renderActiveEffects(builder *strings.Builder, g *Game) {
-	if len(g.PowerMgr.ActivePowerUps) == 0 {
-		builder.WriteString(strings.Repeat(" ", r.Config.OffsetX-1))
-		builder.WriteString("Active Effects: None" + strings.Repeat(" ", g.State.Config.TermWidth))
-		return
+func mirror(glyph string) string {
+	pairs := map[rune]rune{'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{', '<': '>', '>': '<', '/': '\\', '\\': '/'}
+	runes := []rune(glyph)
+	mirrored := make([]rune, len(runes))
+	for i, r := range runes {
+		if m, ok := pairs[r]; ok {
+			r = m
+		}
+		mirrored[len(runes)-1-i] = r
 	}
+	return string(mirrored)
+}
 
-	builder.WriteString(strings.Repeat(" ", r.Config.OffsetX-1))
-	builder.WriteString("Active Effects: ")
+func powerUpInfo(t *theme.Theme, typ util.PowerUpType) (symbol, effect string) {
+	switch typ {
+	case util.SpeedUp:
+		return t.Glyphs.SpeedUp, "Speed Up"
+	case util.SlowDown:
+		return t.Glyphs.SlowDown, "Slow Down"
+	case util.GhostMode:
+		return t.Glyphs.Ghost, "Ghost Mode"
+	case util.ExtraLength:
+		return t.Glyphs.ExtraLength, "Extra Length"
+	case util.DoublePoints:
+		return t.Glyphs.DoublePoints, "Double Points"
+	}
+	return "", ""
+}
 
-	for _, powerup := range g.PowerMgr.ActivePowerUps {
-		remaining := time.Until(powerup.EndTime).Seconds()
+func activeEffects(t *theme.Theme, s *engine.Snapshot) string {
+	if len(s.ActivePowerUps) == 0 {
+		return "Active Effects: None"
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Active Effects: ")
+	for _, powerup := range s.ActivePowerUps {
+		remaining := powerup.Remaining(s.Clock).Seconds()
 		if remaining <= 0 {
 			continue
 		}
 
-		symbol := ""
-		effect := ""
-		switch powerup.Type {
-		case util.SpeedUp:
-			symbol = "⚡"
-			effect = "Speed Up"
-		case util.SlowDown:
-			symbol = "⏳"
-			effect = "Slow Down"
-		case util.GhostMode:
-			symbol = "👻"
-			effect = "Ghost Mode"
-		case util.ExtraLength:
-			symbol = "🔄"
-			effect = "Extra Length"
-		case util.DoublePoints:
-			symbol = "💎"
-			effect = "Double Points"
+		symbol, effect := powerUpInfo(t, powerup.Type)
+		left := 1.0
+		if powerup.Duration > 0 {
+			left = remaining / powerup.Duration.Seconds()
 		}
+		timer := t.Paint(timerColor(t, left), fmt.Sprintf("(%.1fs)", remaining))
+		builder.WriteString(fmt.Sprintf("%s %s %s ", symbol, effect, timer))
+	}
+	return builder.String()
+}
 
-		builder.WriteString(fmt.Sprintf("%s %s (%.1fs) ", symbol, effect, remaining))
+// timeLeft is the time attack clock. It is colored by how much is left of
+// the starting budget.
+func timeLeft(t *theme.Theme, s *engine.Snapshot) string {
+	left := max(s.Deadline-s.Clock, 0)
+	fraction := 1.0
+	if s.Config.Duration > 0 {
+		fraction = min(left.Seconds()/s.Config.Duration.Seconds(), 1)
+	}
+	minutes, seconds := int(left/time.Minute), (left % time.Minute).Seconds()
+	timer := t.Paint(timerColor(t, fraction), fmt.Sprintf("%d:%04.1f", minutes, seconds))
+	return fmt.Sprintf("Time Left: %s   (+%s per food)", timer, util.TimeAttackBonus)
+}
 
+// arenaTimer counts down to the survival arena's next shrink.
+func arenaTimer(t *theme.Theme, s *engine.Snapshot) string {
+	width, height := s.Config.TermWidth-2*s.Inset, s.Config.TermHeight-2*s.Inset
+	if width-2 < util.MinArena || height-2 < util.MinArena {
+		return "Arena: " + strconv.Itoa(width) + "x" + strconv.Itoa(height) + " (final size)"
+	}
+	left := max(time.Duration(s.Inset+1)*util.ShrinkInterval-s.Clock, 0)
+	timer := t.Paint(timerColor(t, left.Seconds()/util.ShrinkInterval.Seconds()), fmt.Sprintf("%.1fs", left.Seconds()))
+	return fmt.Sprintf("Arena: %dx%d   Closes in %s", width, height, timer)
+}
+
+// timerColor fades from the timer color to the low timer color as a
+// power-up runs out, or switches between them with fewer colors.
+func timerColor(t *theme.Theme, left float64) string {
+	if t.Gradients() {
+		return theme.Blend(t.Colors.TimerLow, t.Colors.Timer, left)
+	}
+	if left > 0.3 {
+		return t.Colors.Timer
 	}
-	builder.WriteString(strings.Repeat(" ", g.State.Config.TermWidth-2*g.State.Config.OffsetX))
-	builder.WriteString("\n")
+	return t.Colors.TimerLow
 }
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package game
+
+import (
+	"testing"
+
+	"gosnake/engine"
+	"gosnake/internal/theme"
+)
+
+type countingWriter struct {
+	n int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += len(p)
+	return len(p), nil
+}
+
+// frames plays a short game on a 40x20 board without walls, so the snake
+// never dies, and returns a snapshot of every tick.
+func frames(b *testing.B) []*engine.Snapshot {
+	config := engine.DefaultConfig()
+	config.Mode = engine.NoWalls
+	config.Seed = 1
+	e := engine.New(config)
+	e.State.RelaxedMode = true
+	e.Init()
+
+	var snapshots []*engine.Snapshot
+	for i := 0; i < 200; i++ {
+		direction := engine.DirectionRight
+		if i%15 == 0 {
+			direction = engine.DirectionDown
+		}
+		if e.Step(engine.Input{Direction: direction}).GameOver {
+			b.Fatalf("game over after %d ticks", i)
+		}
+		snapshots = append(snapshots, e.Snapshot())
+	}
+	return snapshots
+}
+
+func benchmarkRender(b *testing.B, fullRedraw bool) {
+	t, _ := theme.Builtin("classic")
+	snapshots := frames(b)
+	out := &countingWriter{}
+	r := NewANSIRenderer(out, t)
+	r.FullRedraw = fullRedraw
+	// The first frame is always drawn in full.
+	r.Render(snapshots[0])
+	out.n = 0
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Render(snapshots[1+i%(len(snapshots)-1)])
+	}
+	b.ReportMetric(float64(out.n)/float64(b.N), "bytes/frame")
+}
+
+func BenchmarkRenderDiff(b *testing.B) { benchmarkRender(b, false) }
+
+func BenchmarkRenderFull(b *testing.B) { benchmarkRender(b, true) }
@@ -0,0 +1,183 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"gosnake/engine"
+	"gosnake/internal/replay"
+	"gosnake/internal/theme"
+	"gosnake/internal/util"
+
+	"github.com/eiannone/keyboard"
+)
+
+const (
+	replaySeekTicks   = 50
+	replayFastForward = 4
+)
+
+type replayPlayer struct {
+	replay   *replay.Replay
+	game     *Game
+	theme    *theme.Theme
+	renderer *ANSIRenderer
+	tick     int
+	paused   bool
+	fast     bool
+	tooSmall bool
+}
+
+// PlayReplay plays r back through the regular renderer until the user quits.
+func PlayReplay(r *replay.Replay, t *theme.Theme) error {
+	if err := keyboard.Open(); err != nil {
+		return fmt.Errorf("initializing keyboard input: %w", err)
+	}
+	defer keyboard.Close()
+
+	killSig()
+
+	util.ClearScreen()
+	util.HideCursor()
+	defer util.ShowCursor()
+
+	keys := make(chan keyboard.KeyEvent, 10)
+	go func() {
+		for {
+			char, key, err := keyboard.GetKey()
+			if err != nil {
+				return
+			}
+			keys <- keyboard.KeyEvent{Key: key, Rune: char}
+		}
+	}()
+
+	p := &replayPlayer{replay: r, theme: t, renderer: NewRenderer(t)}
+	p.reset()
+
+	ticker := time.NewTicker(p.interval())
+	defer ticker.Stop()
+
+	resized, stopResize := watchResize()
+	defer stopResize()
+
+	p.handleResize()
+	for {
+		select {
+		case <-resized:
+			p.handleResize()
+			continue
+		case event := <-keys:
+			switch {
+			case event.Key == keyboard.KeyEsc || event.Rune == 'q' || event.Rune == 'Q':
+				return nil
+			case event.Key == keyboard.KeySpace || event.Rune == 'p' || event.Rune == 'P':
+				p.paused = !p.paused || p.tooSmall
+			case event.Rune == 'f' || event.Rune == 'F':
+				p.fast = !p.fast
+			case event.Rune == '.':
+				p.paused = true
+				p.step()
+			case event.Key == keyboard.KeyArrowLeft:
+				p.seek(p.tick - replaySeekTicks)
+			case event.Key == keyboard.KeyArrowRight:
+				p.seek(p.tick + replaySeekTicks)
+			case event.Key == keyboard.KeyHome:
+				p.seek(0)
+			}
+			ticker.Reset(p.interval())
+		case <-ticker.C:
+			if p.paused {
+				continue
+			}
+			if p.step().SpeedChanged {
+				ticker.Reset(p.interval())
+			}
+		}
+		p.render()
+	}
+}
+
+func (p *replayPlayer) reset() {
+	config := p.replay.Header.Config
+	config.OffsetX, config.OffsetY = util.CenterOffsets(config.TermWidth, config.TermHeight)
+
+	p.game = &Game{Engine: engine.New(&config)}
+	p.game.SetRelaxedMode(p.replay.Header.Relaxed)
+	p.game.Init()
+	p.tick = 0
+}
+
+// handleResize re-centers the recorded board, which keeps its size, or
+// pauses if the terminal is too small for it.
+func (p *replayPlayer) handleResize() {
+	config := p.game.State.Config
+	p.renderer.Invalidate()
+	p.tooSmall = !util.BoardFits(config.TermWidth, config.TermHeight)
+	if p.tooSmall {
+		p.paused = true
+		renderTooSmall(p.theme, config, "Q")
+		return
+	}
+	config.OffsetX, config.OffsetY = util.CenterOffsets(config.TermWidth, config.TermHeight)
+	p.render()
+}
+
+func (p *replayPlayer) finished() bool {
+	return p.tick >= len(p.replay.Inputs) || p.game.State.ExitGame
+}
+
+func (p *replayPlayer) step() engine.Events {
+	if p.finished() {
+		p.paused = true
+		return engine.Events{}
+	}
+	ev := p.game.Step(engine.Input{Direction: p.replay.Inputs[p.tick]})
+	p.tick++
+	return ev
+}
+
+func (p *replayPlayer) seek(target int) {
+	target = max(0, min(target, len(p.replay.Inputs)))
+	if target < p.tick {
+		p.reset()
+		p.renderer.Invalidate()
+	}
+	for p.tick < target && !p.finished() {
+		p.step()
+	}
+}
+
+func (p *replayPlayer) interval() time.Duration {
+	interval := p.game.State.Config.Speed
+	if p.fast {
+		interval /= replayFastForward
+	}
+	return max(interval, time.Millisecond)
+}
+
+func (p *replayPlayer) render() {
+	if p.tooSmall {
+		return
+	}
+
+	status := "PLAYING"
+	switch {
+	case p.finished():
+		status = "END"
+	case p.paused:
+		status = "PAUSED"
+	case p.fast:
+		status = fmt.Sprintf("%dx", replayFastForward)
+	}
+	p.renderer.Footer = fmt.Sprintf("Replay tick %d/%d  %-7s  [space] pause  [f] fast  [.] step  [<-/->] seek  [home] restart  [q] quit",
+		p.tick, len(p.replay.Inputs), status)
+
+	p.game.State.PauseGame = p.paused
+	p.renderer.Render(p.game.Snapshot())
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+//go:build !windows
+
+package game
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize reports terminal resizes until stop is called.
+func watchResize() (resized <-chan struct{}, stop func()) {
+	sig := make(chan os.Signal, 1)
+	out := make(chan struct{}, 1)
+	done := make(chan struct{})
+	signal.Notify(sig, syscall.SIGWINCH)
+
+	go func() {
+		for {
+			select {
+			case <-sig:
+				select {
+				case out <- struct{}{}:
+				default:
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return out, func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+//go:build windows
+
+package game
+
+import (
+	"time"
+
+	"gosnake/internal/util"
+)
+
+// watchResize reports terminal resizes until stop is called. Windows has no
+// SIGWINCH, so the console size is polled.
+func watchResize() (resized <-chan struct{}, stop func()) {
+	out := make(chan struct{}, 1)
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+
+		width, height, _ := util.TerminalSize()
+		for {
+			select {
+			case <-ticker.C:
+				w, h, err := util.TerminalSize()
+				if err != nil || (w == width && h == height) {
+					continue
+				}
+				width, height = w, h
+				select {
+				case out <- struct{}{}:
+				default:
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return out, func() { close(done) }
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package game
+
+import (
+	"fmt"
+	"gosnake/engine"
+	"gosnake/internal/replay"
+	"gosnake/internal/savegame"
+)
+
+// Resume continues a saved game. Start then skips the main menu and opens
+// the game paused.
+func (g *Game) Resume(s *savegame.Game) error {
+	e, err := engine.Restore(s.State)
+	if err != nil {
+		return err
+	}
+
+	// Older saves only kept the starting speed.
+	start := *e.State.Config
+	start.Speed = s.StartSpeed
+	if s.Start != nil {
+		start = *s.Start
+	}
+	if err := start.Validate(); err != nil {
+		return fmt.Errorf("starting config: %w", err)
+	}
+
+	g.Engine = e
+	g.start = start
+	g.recorder = nil
+	if s.Replay != nil {
+		g.recorder = replay.Resume(s.Replay)
+	}
+	g.resumed = true
+	return nil
+}
+
+// save writes the game that was just left with the save key.
+func (g *Game) save() (string, error) {
+	state, err := g.Engine.Save()
+	if err != nil {
+		return "", err
+	}
+
+	s := &savegame.Game{
+		State:      state,
+		StartSpeed: g.start.Speed,
+		Start:      &g.start,
+	}
+	if g.recorder != nil {
+		s.Replay = g.recorder.Replay(g.State.Score)
+	}
+	return savegame.Save(s)
+}
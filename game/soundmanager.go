@@ -21,6 +21,9 @@ type SoundManager struct {
 	enabled bool
 }
 
+const musicFile = "./assets/background.mp3"
+
+var musicStarted bool
 var musicCtrl *beep.Ctrl
 var musicDone chan bool
 var musicPaused bool
@@ -56,8 +59,18 @@ func (s *SoundManager) PlayGameOver() {
 	go beeep.Beep(320, 300)
 }
 
+// SetEnabled turns sound on or off. The music starts the first time sound
+// is turned on.
+func (s *SoundManager) SetEnabled(enabled bool) {
+	s.enabled = enabled
+	if enabled && !musicStarted {
+		musicStarted = true
+		go PlayMusic(musicFile, -1) // Loop forever
+	}
+}
+
 func (s *SoundManager) ToggleSound() {
-	s.enabled = !s.enabled
+	s.SetEnabled(!s.enabled)
 }
 
 func (s *SoundManager) PauseMusic() {
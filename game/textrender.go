@@ -0,0 +1,63 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package game
+
+import (
+	"fmt"
+	"gosnake/engine"
+	"gosnake/internal/theme"
+	"gosnake/internal/util"
+	"io"
+	"strings"
+)
+
+// TextRenderer writes every frame as plain text, without colors or cursor
+// movement, followed by an empty line. It suits logs, pipes and tests.
+type TextRenderer struct {
+	out   io.Writer
+	theme *theme.Theme
+}
+
+func NewTextRenderer(out io.Writer, t *theme.Theme) *TextRenderer {
+	plain := *t
+	plain.Profile = theme.Monochrome
+	return &TextRenderer{out: out, theme: &plain}
+}
+
+func (r *TextRenderer) Render(s *engine.Snapshot) {
+	var builder strings.Builder
+
+	frame := frameGlyph(r.theme, s)
+	border := strings.Repeat(frame, 2*(s.Config.TermWidth+1))
+	builder.WriteString(border + "\n")
+	for x := 0; x < s.Config.TermHeight; x++ {
+		builder.WriteString(frame)
+		for y := 0; y < s.Config.TermWidth; y++ {
+			builder.WriteString(cellGlyph(r.theme, s, x, y))
+		}
+		builder.WriteString(frame + "\n")
+	}
+	builder.WriteString(border + "\n")
+
+	fmt.Fprintf(&builder, "Score: %d\n", s.Score)
+	switch {
+	case s.GameOver && s.ExitCode == util.Victory:
+		builder.WriteString("You Win\n")
+	case s.GameOver:
+		builder.WriteString("Game Over\n")
+	case s.Paused:
+		builder.WriteString("PAUSED\n")
+	case s.Config.Mode == util.PowerUps:
+		builder.WriteString(strings.TrimSpace(activeEffects(r.theme, s)) + "\n")
+	case s.Config.Mode == util.TimeAttack:
+		builder.WriteString(timeLeft(r.theme, s) + "\n")
+	case s.Config.Mode == util.Survival:
+		builder.WriteString(arenaTimer(r.theme, s) + "\n")
+	}
+	builder.WriteString("\n")
+
+	io.WriteString(r.out, builder.String())
+}
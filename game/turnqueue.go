@@ -0,0 +1,47 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package game
+
+import "gosnake/internal/util"
+
+// turnQueue holds the turns pressed since the last tick, so two quick
+// presses such as up then left are played on two ticks instead of the
+// second one replacing, or reversing, the first.
+type turnQueue struct {
+	turns []int
+	depth int
+}
+
+func newTurnQueue(depth int) turnQueue {
+	return turnQueue{depth: max(depth, 1)}
+}
+
+// push queues direction unless the queue is full or it does not turn the
+// snake: the same or the opposite of the direction it will be moving in
+// after the turns already queued.
+func (q *turnQueue) push(direction, moving int) {
+	if n := len(q.turns); n > 0 {
+		moving = q.turns[n-1]
+	}
+	if len(q.turns) >= q.depth || direction == moving || direction == util.OppositeDirection(moving) {
+		return
+	}
+	q.turns = append(q.turns, direction)
+}
+
+// pop returns the next turn, or 0 to keep going straight.
+func (q *turnQueue) pop() int {
+	if len(q.turns) == 0 {
+		return 0
+	}
+	direction := q.turns[0]
+	q.turns = q.turns[1:]
+	return direction
+}
+
+func (q *turnQueue) clear() {
+	q.turns = q.turns[:0]
+}
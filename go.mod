@@ -3,6 +3,7 @@ module gosnake
 go 1.24.2
 
 require (
+	github.com/BurntSushi/toml v1.5.0
 	github.com/eiannone/keyboard v0.0.0-20220611211555-0d226195f203
 	github.com/gen2brain/beeep v0.0.0-20240516210008-9c006672e7f4
 	github.com/spf13/cobra v1.9.1
@@ -0,0 +1,346 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// Package config loads the TOML configuration file. Top-level keys are the
+// defaults, [profiles.<name>] tables override them, and command line flags
+// override both.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gosnake/internal/keymap"
+	"gosnake/internal/theme"
+	"gosnake/internal/util"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Settings is one layer of configuration. Nil fields are not set by that
+// layer and fall through to the one below. The glyph keys override single
+// glyphs of the selected theme.
+type Settings struct {
+	Mode       *string `toml:"mode,omitempty"`
+	Speed      *int    `toml:"speed,omitempty"`
+	Duration   *int    `toml:"duration,omitempty"`
+	Relaxed    *bool   `toml:"relaxed,omitempty"`
+	Sound      *bool   `toml:"sound,omitempty"`
+	Name       *string `toml:"name,omitempty"`
+	Width      *int    `toml:"width,omitempty"`
+	Height     *int    `toml:"height,omitempty"`
+	Theme      *string `toml:"theme,omitempty"`
+	ASCII      *bool   `toml:"ascii,omitempty"`
+	QueueDepth *int    `toml:"queue_depth,omitempty"`
+	BorderChar *string `toml:"border_char,omitempty"`
+	MazeChar   *string `toml:"maze_char,omitempty"`
+	EmptyCell  *string `toml:"empty_cell,omitempty"`
+	SnakeCell  *string `toml:"snake_cell,omitempty"`
+	SnakeHead  *string `toml:"snake_head,omitempty"`
+	FoodCell   *string `toml:"food_cell,omitempty"`
+
+	// Keys maps action names to key names. Actions not listed keep the
+	// keys of the layer below.
+	Keys map[string][]string `toml:"keys,omitempty"`
+}
+
+type File struct {
+	Settings
+	Profile  string              `toml:"profile,omitempty"`
+	Profiles map[string]Settings `toml:"profiles,omitempty"`
+}
+
+// Path is $XDG_CONFIG_HOME/gosnake/config.toml or the platform equivalent.
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gosnake", "config.toml"), nil
+}
+
+// Load reads the config file at path. A missing file is an empty config.
+func Load(path string) (*File, error) {
+	f := &File{}
+	meta, err := toml.DecodeFile(path, f)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("%s: unknown key %q", path, undecoded[0].String())
+	}
+	return f, nil
+}
+
+// Resolve returns the top-level settings overridden by the named profile,
+// or by the file's default profile when name is empty.
+func (f *File) Resolve(name string) (Settings, error) {
+	if name == "" {
+		name = f.Profile
+	}
+	if name == "" {
+		return f.Settings, nil
+	}
+	profile, ok := f.Profiles[name]
+	if !ok {
+		return Settings{}, fmt.Errorf("unknown profile %q", name)
+	}
+	return f.Settings.Merge(profile), nil
+}
+
+// Merge returns s with every field that over sets replaced.
+func (s Settings) Merge(over Settings) Settings {
+	merge(&s.Mode, over.Mode)
+	merge(&s.Speed, over.Speed)
+	merge(&s.Duration, over.Duration)
+	merge(&s.Relaxed, over.Relaxed)
+	merge(&s.Sound, over.Sound)
+	merge(&s.Name, over.Name)
+	merge(&s.Width, over.Width)
+	merge(&s.Height, over.Height)
+	merge(&s.Theme, over.Theme)
+	merge(&s.ASCII, over.ASCII)
+	merge(&s.QueueDepth, over.QueueDepth)
+	merge(&s.BorderChar, over.BorderChar)
+	merge(&s.MazeChar, over.MazeChar)
+	merge(&s.EmptyCell, over.EmptyCell)
+	merge(&s.SnakeCell, over.SnakeCell)
+	merge(&s.SnakeHead, over.SnakeHead)
+	merge(&s.FoodCell, over.FoodCell)
+	if len(over.Keys) > 0 {
+		keys := make(map[string][]string, len(s.Keys)+len(over.Keys))
+		for action, names := range s.Keys {
+			keys[action] = names
+		}
+		for action, names := range over.Keys {
+			keys[action] = names
+		}
+		s.Keys = keys
+	}
+	return s
+}
+
+func merge[T any](dst **T, src *T) {
+	if src != nil {
+		*dst = src
+	}
+}
+
+// Validate checks every value that is set, without touching the terminal.
+func (s Settings) Validate() error {
+	var errs []error
+	if s.Mode != nil {
+		if _, err := util.ParseGameMode(*s.Mode); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.Speed != nil && *s.Speed <= 0 {
+		errs = append(errs, fmt.Errorf("speed must be positive, got %d", *s.Speed))
+	}
+	if s.Duration != nil && *s.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("duration must be positive, got %d", *s.Duration))
+	}
+	if s.Width != nil && (*s.Width < util.MinBoardWidth || *s.Width > util.MaxBoardWidth) {
+		errs = append(errs, fmt.Errorf("width must be between %d and %d, got %d", util.MinBoardWidth, util.MaxBoardWidth, *s.Width))
+	}
+	if s.Height != nil && (*s.Height < util.MinBoardHeight || *s.Height > util.MaxBoardHeight) {
+		errs = append(errs, fmt.Errorf("height must be between %d and %d, got %d", util.MinBoardHeight, util.MaxBoardHeight, *s.Height))
+	}
+	if s.QueueDepth != nil && (*s.QueueDepth < 1 || *s.QueueDepth > util.MaxQueueDepth) {
+		errs = append(errs, fmt.Errorf("queue_depth must be between 1 and %d, got %d", util.MaxQueueDepth, *s.QueueDepth))
+	}
+	if _, err := keymap.Parse(s.Keys); err != nil {
+		errs = append(errs, fmt.Errorf("keys: %w", err))
+	}
+	if s.Theme != nil && *s.Theme == "" {
+		errs = append(errs, errors.New("theme must not be empty"))
+	}
+	for key, glyph := range map[string]*string{
+		"border_char": s.BorderChar, "maze_char": s.MazeChar, "empty_cell": s.EmptyCell,
+		"snake_cell": s.SnakeCell, "snake_head": s.SnakeHead, "food_cell": s.FoodCell,
+	} {
+		if glyph != nil && *glyph == "" {
+			errs = append(errs, fmt.Errorf("%s must not be empty", key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the top-level settings and every profile.
+func (f *File) Validate() error {
+	var errs []error
+	if err := f.Settings.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if f.Profile != "" {
+		if _, ok := f.Profiles[f.Profile]; !ok {
+			errs = append(errs, fmt.Errorf("default profile %q is not defined", f.Profile))
+		}
+	}
+	for name, profile := range f.Profiles {
+		if err := profile.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("profile %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Apply copies the settings into config. The board size is applied last
+// because it is checked against the terminal.
+func (s Settings) Apply(config *util.GameConfig) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	if s.Mode != nil {
+		config.Mode, _ = util.ParseGameMode(*s.Mode)
+	}
+	if s.Speed != nil {
+		config.Speed = time.Duration(*s.Speed) * time.Millisecond
+	}
+	if s.Duration != nil {
+		config.Duration = time.Duration(*s.Duration) * time.Second
+	}
+	if s.Width != nil || s.Height != nil {
+		width, height := config.TermWidth, config.TermHeight
+		apply(&width, s.Width)
+		apply(&height, s.Height)
+		return config.SetBoardSize(width, height)
+	}
+	return nil
+}
+
+func apply[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+// LoadTheme loads the selected theme with the glyph keys applied to it. In
+// ASCII mode, forced by the ascii key or detected from the locale, glyphs
+// that are not plain ASCII are replaced.
+func (s Settings) LoadTheme() (*theme.Theme, error) {
+	name := ""
+	if s.Theme != nil {
+		name = *s.Theme
+	}
+	t, err := theme.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	apply(&t.Glyphs.Border, s.BorderChar)
+	apply(&t.Glyphs.Maze, s.MazeChar)
+	apply(&t.Glyphs.Empty, s.EmptyCell)
+	apply(&t.Glyphs.SnakeBody, s.SnakeCell)
+	apply(&t.Glyphs.SnakeHead, s.SnakeHead)
+	apply(&t.Glyphs.Food, s.FoodCell)
+
+	ascii := theme.DetectASCII()
+	apply(&ascii, s.ASCII)
+	if ascii {
+		t = t.ASCII()
+	}
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("theme %s: %w", t.Name, err)
+	}
+	return t, nil
+}
+
+// Keymap returns the key bindings, the defaults overridden by Keys.
+func (s Settings) Keymap() (keymap.Keymap, error) {
+	km, err := keymap.Parse(s.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("keys: %w", err)
+	}
+	return km, nil
+}
+
+// Defaults are the built-in settings, as a config file would spell them.
+// The board size is left out because it follows the terminal, the glyphs
+// because they come from the theme, and ascii because it follows the
+// locale.
+func Defaults() Settings {
+	config := util.DefaultGameConfig()
+	return Settings{
+		Mode:       ptr(config.Mode.String()),
+		Speed:      ptr(int(config.Speed / time.Millisecond)),
+		Duration:   ptr(int(config.Duration / time.Second)),
+		Relaxed:    ptr(false),
+		Sound:      ptr(true),
+		Theme:      ptr(theme.Default),
+		QueueDepth: ptr(util.DefaultQueueDepth),
+		Keys:       keymap.Default().Names(),
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// Template is what "gosnake config init" writes.
+const Template = `# GoSnake configuration.
+#
+# Top-level keys apply to every game. A [profiles.<name>] table overrides
+# them when selected with --profile <name> (or with "profile" below).
+# Command line flags override both.
+
+# profile = "competitive"
+
+# mode = "normal"        # normal, nowalls, maze, powerups, timeattack, survival
+# speed = 200            # starting tick length in milliseconds
+# duration = 120         # time attack budget in seconds; each food adds 5
+# relaxed = false        # keep the speed constant
+# sound = true
+# name = "player"        # name saved with high scores
+# queue_depth = 3        # turns remembered when pressed faster than the
+#                        # snake moves, played one per tick
+# width = 40             # board size in cells; derived from the
+# height = 20            # terminal when not set
+
+# theme = "classic"      # classic, ascii, retro-green, high-contrast, or
+#                        # the name of a file in the themes directory
+# ascii = false          # only plain ASCII glyphs; detected from the
+#                        # locale when not set
+
+# Single glyphs of the theme can be replaced. Match the theme's cell_width.
+# border_char = "#"
+# maze_char = "##"
+# empty_cell = "  "
+# snake_cell = "()"
+# snake_head = ":)"      # facing right; mirrored when facing left
+# food_cell = "🍎"
+
+# Key bindings. Each action takes a list of keys: single characters
+# (letters match either case) or Up, Down, Left, Right, Esc, Space, Enter,
+# Tab, Backspace, Insert, Delete, Home, End, PgUp, PgDn, F1-F12. Actions
+# left out keep their default keys.
+# [keys]
+# up = ["Up", "w", "k"]
+# down = ["Down", "s", "j"]
+# left = ["Left", "a", "h"]
+# right = ["Right", "d", "l"]
+# pause = ["p"]
+# quit = ["q", "Esc"]
+# mute = ["m"]
+# restart = ["r"]
+# save = ["x"]
+
+[profiles.competitive]
+mode = "maze"
+speed = 150
+width = 40
+height = 20
+
+[profiles.chill]
+relaxed = true
+speed = 250
+`
@@ -0,0 +1,203 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package highscore
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gosnake/internal/util"
+)
+
+type Filter struct {
+	Mode   *util.GameMode
+	Player string
+	Since  time.Time
+	Until  time.Time
+}
+
+func (f Filter) Match(e Entry) bool {
+	if f.Mode != nil && (e.Legacy || e.Mode != *f.Mode) {
+		return false
+	}
+	if f.Player != "" && !strings.EqualFold(f.Player, e.Player) {
+		return false
+	}
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !e.Time.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+func (f Filter) Apply(entries []Entry) []Entry {
+	var matched []Entry
+	for _, e := range entries {
+		if f.Match(e) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+var csvHeader = []string{"player", "score", "mode", "relaxed", "speed_ms", "width", "height", "length", "duration_ms", "time", "legacy", "won", "budget_s"}
+
+func WriteCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		err := cw.Write([]string{
+			e.Player,
+			strconv.Itoa(e.Score),
+			e.Mode.String(),
+			strconv.FormatBool(e.Relaxed),
+			strconv.Itoa(e.SpeedMS),
+			strconv.Itoa(e.Width),
+			strconv.Itoa(e.Height),
+			strconv.Itoa(e.Length),
+			strconv.FormatInt(e.DurationMS, 10),
+			e.Time.Format(time.RFC3339Nano),
+			strconv.FormatBool(e.Legacy),
+			strconv.FormatBool(e.Won),
+			strconv.Itoa(e.BudgetS),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func ReadCSV(r io.Reader) ([]Entry, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"player", "score"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing %q column", name)
+		}
+	}
+
+	var entries []Entry
+	for n, record := range records[1:] {
+		line := n + 2
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		atoi := func(name string) (int, error) {
+			if field(name) == "" {
+				return 0, nil
+			}
+			v, err := strconv.Atoi(field(name))
+			if err != nil {
+				return 0, fmt.Errorf("line %d: %s: %w", line, name, err)
+			}
+			return v, nil
+		}
+
+		var e Entry
+		var err error
+		e.Player = field("player")
+		if e.Score, err = atoi("score"); err != nil {
+			return nil, err
+		}
+		if field("mode") != "" {
+			if e.Mode, err = util.ParseGameMode(field("mode")); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		e.Relaxed = field("relaxed") == "true"
+		e.Legacy = field("legacy") == "true"
+		e.Won = field("won") == "true"
+		if e.SpeedMS, err = atoi("speed_ms"); err != nil {
+			return nil, err
+		}
+		if e.Width, err = atoi("width"); err != nil {
+			return nil, err
+		}
+		if e.Height, err = atoi("height"); err != nil {
+			return nil, err
+		}
+		if e.Length, err = atoi("length"); err != nil {
+			return nil, err
+		}
+		if e.BudgetS, err = atoi("budget_s"); err != nil {
+			return nil, err
+		}
+		duration, err := atoi("duration_ms")
+		if err != nil {
+			return nil, err
+		}
+		e.DurationMS = int64(duration)
+		if field("time") != "" {
+			if e.Time, err = time.Parse(time.RFC3339Nano, field("time")); err != nil {
+				return nil, fmt.Errorf("line %d: time: %w", line, err)
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func WriteJSON(w io.Writer, entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+func ReadJSON(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Merge adds the imported entries that are not stored yet and returns how
+// many were added.
+func (s *Store) Merge(imported []Entry) (int, error) {
+	added := 0
+	err := s.Update(func(entries []Entry) []Entry {
+		added = 0
+	next:
+		for _, e := range imported {
+			for _, existing := range entries {
+				if existing.Same(e) {
+					continue next
+				}
+			}
+			entries = append(entries, e)
+			added++
+		}
+		return entries
+	})
+	return added, err
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// Package highscore keeps finished games in a JSON file under the data dir.
+// Writes are atomic and guarded by a lock file, so several games running at
+// once never lose each other's scores.
+package highscore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gosnake/internal/util"
+)
+
+const (
+	fileName    = "scores.json"
+	fileVersion = 1
+)
+
+type Entry struct {
+	Player     string        `json:"player"`
+	Score      int           `json:"score"`
+	Mode       util.GameMode `json:"mode"`
+	Relaxed    bool          `json:"relaxed"`
+	SpeedMS    int           `json:"speed_ms"`
+	Width      int           `json:"width"`
+	Height     int           `json:"height"`
+	Length     int           `json:"length"`
+	DurationMS int64         `json:"duration_ms"`
+	Time       time.Time     `json:"time"`
+	BudgetS    int           `json:"budget_s,omitempty"` // the time attack budget
+	Won        bool          `json:"won,omitempty"`      // the snake filled the board
+	Legacy     bool          `json:"legacy,omitempty"`   // imported from Score.txt, settings unknown
+}
+
+func (e Entry) Speed() time.Duration {
+	return time.Duration(e.SpeedMS) * time.Millisecond
+}
+
+func (e Entry) Budget() time.Duration {
+	return time.Duration(e.BudgetS) * time.Second
+}
+
+func (e Entry) Duration() time.Duration {
+	return time.Duration(e.DurationMS) * time.Millisecond
+}
+
+type scoreFile struct {
+	Version int     `json:"version"`
+	Scores  []Entry `json:"scores"`
+}
+
+type Store struct {
+	path string
+}
+
+// Open returns the store in the data dir, importing any Score.txt left in
+// the working directory by older versions.
+func Open() (*Store, error) {
+	dir, err := util.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{path: filepath.Join(dir, fileName)}
+	if err := s.migrateLegacy("Score.txt"); err != nil {
+		return s, fmt.Errorf("importing Score.txt: %w", err)
+	}
+	return s, nil
+}
+
+func OpenPath(path string) *Store {
+	return &Store{path: path}
+}
+
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load returns every entry, best score first.
+func (s *Store) Load() ([]Entry, error) {
+	entries, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	Sort(entries)
+	return entries, nil
+}
+
+func (s *Store) Add(e Entry) error {
+	return s.Update(func(entries []Entry) []Entry {
+		return append(entries, e)
+	})
+}
+
+// Update replaces the stored entries with whatever fn returns, holding the
+// lock for the whole read-modify-write.
+func (s *Store) Update(fn func([]Entry) []Entry) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	unlock, err := lock(s.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+	return s.write(fn(entries))
+}
+
+func (s *Store) read() ([]Entry, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f scoreFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%s: %w", s.path, err)
+	}
+	if f.Version > fileVersion {
+		return nil, fmt.Errorf("%s: written by a newer version of gosnake (format %d)", s.path, f.Version)
+	}
+	return f.Scores, nil
+}
+
+func (s *Store) write(entries []Entry) error {
+	Sort(entries)
+	data, err := json.MarshalIndent(scoreFile{Version: fileVersion, Scores: entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), fileName+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// Sort orders entries best first; ties go to the older game.
+func Sort(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].Time.Before(entries[j].Time)
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package highscore
+
+import (
+	"fmt"
+	"time"
+
+	"gosnake/internal/util"
+)
+
+type SpeedBucket int
+
+const (
+	SpeedFast SpeedBucket = iota
+	SpeedNormal
+	SpeedSlow
+)
+
+func BucketFor(speed time.Duration) SpeedBucket {
+	switch {
+	case speed <= 100*time.Millisecond:
+		return SpeedFast
+	case speed <= 200*time.Millisecond:
+		return SpeedNormal
+	default:
+		return SpeedSlow
+	}
+}
+
+func (b SpeedBucket) String() string {
+	switch b {
+	case SpeedFast:
+		return "fast"
+	case SpeedSlow:
+		return "slow"
+	default:
+		return "normal"
+	}
+}
+
+// Board identifies a leaderboard. Scores are only compared with games
+// played in the same mode, relaxed setting and speed bucket, and in time
+// attack with the same time budget.
+type Board struct {
+	Mode    util.GameMode
+	Relaxed bool
+	Speed   SpeedBucket
+	Budget  time.Duration // time attack only
+}
+
+// DefaultBoard is where scores imported from Score.txt are listed; older
+// versions always played normal mode at 200ms.
+var DefaultBoard = Board{Mode: util.Normal, Speed: SpeedNormal}
+
+func BoardFor(mode util.GameMode, relaxed bool, speed, budget time.Duration) Board {
+	if mode != util.TimeAttack {
+		budget = 0
+	}
+	return Board{Mode: mode, Relaxed: relaxed, Speed: BucketFor(speed), Budget: budget}
+}
+
+func (e Entry) Board() Board {
+	if e.Legacy {
+		return DefaultBoard
+	}
+	budget := e.Budget()
+	if budget == 0 {
+		// Time attack scores from before the budget was recorded.
+		budget = util.DefaultDuration
+	}
+	return BoardFor(e.Mode, e.Relaxed, e.Speed(), budget)
+}
+
+func (b Board) String() string {
+	s := fmt.Sprintf("%s, %s speed", b.Mode, b.Speed)
+	if b.Budget > 0 {
+		s += fmt.Sprintf(", %s budget", b.Budget)
+	}
+	if b.Relaxed {
+		s += ", relaxed"
+	}
+	return s
+}
+
+// Leaderboard returns the entries on board b, best first.
+func Leaderboard(entries []Entry, b Board) []Entry {
+	var board []Entry
+	for _, e := range entries {
+		if e.Board() == b {
+			board = append(board, e)
+		}
+	}
+	Sort(board)
+	return board
+}
+
+// Rank returns the 1-based position of e in board, or 0 if it is not there.
+func Rank(board []Entry, e Entry) int {
+	for i, other := range board {
+		if other.Same(e) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (e Entry) Same(other Entry) bool {
+	return e.Player == other.Player && e.Score == other.Score && e.Time.Equal(other.Time)
+}
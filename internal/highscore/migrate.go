@@ -0,0 +1,66 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package highscore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// migrateLegacy imports the bare scores older versions appended to
+// Score.txt and renames the file so it is only imported once.
+func (s *Store) migrateLegacy(legacyPath string) error {
+	if _, err := os.Stat(legacyPath); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	unlock, err := lock(s.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Another game may have imported it while we waited for the lock.
+	info, err := os.Stat(legacyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(legacyPath)
+	if err != nil {
+		return err
+	}
+
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		score, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil || score <= 0 {
+			continue
+		}
+		entries = append(entries, Entry{
+			Player: "unknown",
+			Score:  score,
+			Time:   info.ModTime(),
+			Legacy: true,
+		})
+	}
+
+	if err := s.write(entries); err != nil {
+		return err
+	}
+	return os.Rename(legacyPath, legacyPath+".imported")
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// Package keymap maps key presses to game actions. Every action can be
+// bound to any number of keys in the config file.
+package keymap
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/eiannone/keyboard"
+)
+
+type Action int
+
+const (
+	Up Action = iota
+	Down
+	Left
+	Right
+	Pause
+	Quit
+	Mute
+	Restart
+	Save
+)
+
+// Actions lists every action in the order they are shown.
+var Actions = []Action{Up, Down, Left, Right, Pause, Quit, Mute, Restart, Save}
+
+var actionNames = map[Action]string{
+	Up:      "up",
+	Down:    "down",
+	Left:    "left",
+	Right:   "right",
+	Pause:   "pause",
+	Quit:    "quit",
+	Mute:    "mute",
+	Restart: "restart",
+	Save:    "save",
+}
+
+func (a Action) String() string {
+	return actionNames[a]
+}
+
+func ParseAction(name string) (Action, error) {
+	for a, n := range actionNames {
+		if strings.EqualFold(name, n) {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown action %q (valid: %s)", name, strings.Join(actionList(), ", "))
+}
+
+func actionList() []string {
+	var names []string
+	for _, a := range Actions {
+		names = append(names, a.String())
+	}
+	return names
+}
+
+// Key is either a special key, such as an arrow key, or a character.
+// Letters match regardless of case.
+type Key struct {
+	Code keyboard.Key
+	Rune rune
+}
+
+var keyNames = map[keyboard.Key]string{
+	keyboard.KeyArrowUp:    "Up",
+	keyboard.KeyArrowDown:  "Down",
+	keyboard.KeyArrowLeft:  "Left",
+	keyboard.KeyArrowRight: "Right",
+	keyboard.KeyEsc:        "Esc",
+	keyboard.KeySpace:      "Space",
+	keyboard.KeyEnter:      "Enter",
+	keyboard.KeyTab:        "Tab",
+	keyboard.KeyBackspace2: "Backspace",
+	keyboard.KeyInsert:     "Insert",
+	keyboard.KeyDelete:     "Delete",
+	keyboard.KeyHome:       "Home",
+	keyboard.KeyEnd:        "End",
+	keyboard.KeyPgup:       "PgUp",
+	keyboard.KeyPgdn:       "PgDn",
+	keyboard.KeyF1:         "F1",
+	keyboard.KeyF2:         "F2",
+	keyboard.KeyF3:         "F3",
+	keyboard.KeyF4:         "F4",
+	keyboard.KeyF5:         "F5",
+	keyboard.KeyF6:         "F6",
+	keyboard.KeyF7:         "F7",
+	keyboard.KeyF8:         "F8",
+	keyboard.KeyF9:         "F9",
+	keyboard.KeyF10:        "F10",
+	keyboard.KeyF11:        "F11",
+	keyboard.KeyF12:        "F12",
+}
+
+// ParseKey accepts a single character or one of the special key names:
+// Up, Down, Left, Right, Esc, Space, Enter, Tab, Backspace, Insert,
+// Delete, Home, End, PgUp, PgDn and F1 to F12.
+func ParseKey(name string) (Key, error) {
+	if utf8.RuneCountInString(name) == 1 {
+		r, _ := utf8.DecodeRuneInString(name)
+		if r == ' ' {
+			return Key{Code: keyboard.KeySpace}, nil
+		}
+		if unicode.IsPrint(r) {
+			return Key{Rune: unicode.ToLower(r)}, nil
+		}
+	}
+	for code, n := range keyNames {
+		if strings.EqualFold(name, n) {
+			return Key{Code: code}, nil
+		}
+	}
+	return Key{}, fmt.Errorf("unknown key %q", name)
+}
+
+func (k Key) String() string {
+	if k.Rune != 0 {
+		return string(unicode.ToUpper(k.Rune))
+	}
+	return keyNames[k.Code]
+}
+
+func (k Key) Matches(event keyboard.KeyEvent) bool {
+	if k.Rune != 0 {
+		return event.Key == 0 && unicode.ToLower(event.Rune) == k.Rune
+	}
+	if k.Code == keyboard.KeySpace && event.Rune == ' ' {
+		return true
+	}
+	return event.Rune == 0 && event.Key == k.Code
+}
+
+// Keymap holds the keys bound to each action.
+type Keymap map[Action][]Key
+
+// Default binds the arrow keys, WASD and HJKL to moving.
+func Default() Keymap {
+	return Keymap{
+		Up:      {{Code: keyboard.KeyArrowUp}, {Rune: 'w'}, {Rune: 'k'}},
+		Down:    {{Code: keyboard.KeyArrowDown}, {Rune: 's'}, {Rune: 'j'}},
+		Left:    {{Code: keyboard.KeyArrowLeft}, {Rune: 'a'}, {Rune: 'h'}},
+		Right:   {{Code: keyboard.KeyArrowRight}, {Rune: 'd'}, {Rune: 'l'}},
+		Pause:   {{Rune: 'p'}},
+		Quit:    {{Rune: 'q'}, {Code: keyboard.KeyEsc}},
+		Mute:    {{Rune: 'm'}},
+		Restart: {{Rune: 'r'}},
+		Save:    {{Rune: 'x'}},
+	}
+}
+
+// Parse builds a keymap from action names to key names, as written in the
+// config file. Actions it leaves out keep their default keys.
+func Parse(bindings map[string][]string) (Keymap, error) {
+	km := Default()
+	for name, keys := range bindings {
+		action, err := ParseAction(name)
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("%s: no keys bound", name)
+		}
+		km[action] = nil
+		for _, key := range keys {
+			k, err := ParseKey(key)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			km[action] = append(km[action], k)
+		}
+	}
+	return km, km.check()
+}
+
+// check refuses keys bound to more than one action.
+func (km Keymap) check() error {
+	bound := make(map[Key]Action)
+	for _, action := range Actions {
+		for _, k := range km[action] {
+			if other, ok := bound[k]; ok && other != action {
+				return fmt.Errorf("key %s is bound to both %s and %s", k, other, action)
+			}
+			bound[k] = action
+		}
+	}
+	return nil
+}
+
+// Lookup returns the action bound to the pressed key.
+func (km Keymap) Lookup(event keyboard.KeyEvent) (Action, bool) {
+	for _, action := range Actions {
+		for _, k := range km[action] {
+			if k.Matches(event) {
+				return action, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Describe lists the keys bound to action, such as "Up/W/K".
+func (km Keymap) Describe(action Action) string {
+	var names []string
+	for _, k := range km[action] {
+		names = append(names, k.String())
+	}
+	return strings.Join(names, "/")
+}
+
+// Names is the keymap as the config file spells it.
+func (km Keymap) Names() map[string][]string {
+	names := make(map[string][]string, len(km))
+	for action, keys := range km {
+		for _, k := range keys {
+			names[action.String()] = append(names[action.String()], k.String())
+		}
+	}
+	return names
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// Package replay reads and writes replay files. A replay is a JSON lines
+// file: the first line is a Header, every following line is the direction
+// input of one tick. Feeding the inputs back into an engine built from the
+// header reproduces the game exactly.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gosnake/internal/util"
+)
+
+// Version changes whenever the engine would play the same inputs out
+// differently, since older replays can no longer be reproduced.
+const Version = 2
+
+type Header struct {
+	Version     int             `json:"version"`
+	GameVersion string          `json:"game_version"`
+	Created     time.Time       `json:"created"`
+	Relaxed     bool            `json:"relaxed"`
+	Config      util.GameConfig `json:"config"`
+	Score       int             `json:"score"`
+	Ticks       int             `json:"ticks"`
+}
+
+type tick struct {
+	Tick      int `json:"t"`
+	Direction int `json:"d"`
+}
+
+type Replay struct {
+	Header Header
+	Inputs []int
+}
+
+type Recorder struct {
+	replay Replay
+}
+
+// NewRecorder snapshots the starting configuration, so it must be created
+// before the game changes any of it.
+func NewRecorder(config *util.GameConfig, relaxed bool) *Recorder {
+	cfg := *config
+	cfg.Obstacles = nil
+	return &Recorder{
+		replay: Replay{
+			Header: Header{
+				Version:     Version,
+				GameVersion: util.VER,
+				Created:     time.Now(),
+				Relaxed:     relaxed,
+				Config:      cfg,
+			},
+		},
+	}
+}
+
+// Resume continues recording r, a game saved part way through.
+func Resume(r *Replay) *Recorder {
+	return &Recorder{replay: *r}
+}
+
+func (r *Recorder) Record(direction int) {
+	r.replay.Inputs = append(r.replay.Inputs, direction)
+}
+
+func (r *Recorder) Replay(score int) *Replay {
+	r.replay.Header.Score = score
+	r.replay.Header.Ticks = len(r.replay.Inputs)
+	return &r.replay
+}
+
+// Dir is where finished games are saved.
+func Dir() (string, error) {
+	dir, err := util.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "replays"), nil
+}
+
+// SaveNew writes r to a fresh, timestamped file in Dir and returns its path.
+func SaveNew(r *Replay) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, r.Header.Created.Format("20060102-150405")+".jsonl")
+	return path, Save(path, r)
+}
+
+func Save(path string, r *Replay) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(r.Header); err != nil {
+		return err
+	}
+	for i, direction := range r.Inputs {
+		if err := enc.Encode(tick{Tick: i, Direction: direction}); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func Load(path string) (*Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	r := &Replay{}
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: empty replay file", path)
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &r.Header); err != nil {
+		return nil, fmt.Errorf("%s: bad header: %w", path, err)
+	}
+	if r.Header.Version != Version {
+		return nil, fmt.Errorf("%s: unsupported replay version %d", path, r.Header.Version)
+	}
+	if err := r.Header.Config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for line := 2; scanner.Scan(); line++ {
+		var t tick
+		if err := json.Unmarshal(scanner.Bytes(), &t); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if t.Tick != len(r.Inputs) {
+			return nil, fmt.Errorf("%s:%d: expected tick %d, got %d", path, line, len(r.Inputs), t.Tick)
+		}
+		r.Inputs = append(r.Inputs, t.Direction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// Package savegame keeps one game in progress on disk, so it can be
+// resumed with "gosnake play --resume".
+package savegame
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gosnake/engine"
+	"gosnake/internal/replay"
+	"gosnake/internal/util"
+)
+
+const (
+	fileName    = "save.json"
+	fileVersion = 1
+)
+
+// ErrNoSave is returned by Load when there is no saved game.
+var ErrNoSave = errors.New("no saved game")
+
+type Game struct {
+	Version     int              `json:"version"`
+	GameVersion string           `json:"game_version"`
+	Created     time.Time        `json:"created"`
+	State       *engine.Saved    `json:"state"`
+	StartSpeed  time.Duration    `json:"start_speed"`      // picks the leaderboard
+	Start       *util.GameConfig `json:"start,omitempty"`  // the config the game began with
+	Replay      *replay.Replay   `json:"replay,omitempty"` // the inputs so far
+}
+
+func Path() (string, error) {
+	dir, err := util.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileName), nil
+}
+
+// Save writes g, replacing any earlier saved game, and returns the path.
+func Save(g *Game) (string, error) {
+	path, err := Path()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+
+	g.Version = fileVersion
+	g.GameVersion = util.VER
+	g.Created = time.Now()
+	data, err := json.Marshal(g)
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), fileName+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	return path, os.Rename(tmp.Name(), path)
+}
+
+func Load() (*Game, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSave
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var g Game
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if g.Version > fileVersion {
+		return nil, fmt.Errorf("%s: written by a newer version of gosnake (format %d)", path, g.Version)
+	}
+	if g.State == nil {
+		return nil, fmt.Errorf("%s: no game state", path)
+	}
+	return &g, nil
+}
+
+// Remove deletes the saved game, so it can only be resumed once.
+func Remove() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package theme
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// asciiWide replaces non-ASCII glyphs in themes with 2-column cells.
+var asciiWide = Glyphs{
+	Border:        "#",
+	Maze:          "##",
+	Empty:         "  ",
+	SnakeBody:     "()",
+	SnakeHead:     ":)",
+	SnakeHeadUp:   "",
+	SnakeHeadDown: "",
+	Food:          "<>",
+	SpeedUp:       ">>",
+	SlowDown:      "<<",
+	Ghost:         "%%",
+	ExtraLength:   "++",
+	DoublePoints:  "$$",
+	Pause:         "||",
+	Trail:         "..",
+}
+
+// DetectASCII reports whether the terminal is unlikely to draw emoji and
+// other wide glyphs at a predictable width: the locale is not UTF-8, or
+// this is the Linux console.
+func DetectASCII() bool {
+	if os.Getenv("TERM") == "linux" {
+		return true
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			locale = strings.ToLower(locale)
+			return !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8")
+		}
+	}
+	// No locale at all is the C locale, except on Windows, which does not
+	// use these variables.
+	return runtime.GOOS != "windows"
+}
+
+// ASCII returns the theme with every glyph that is not plain ASCII replaced
+// by one that is, so every board cell is exactly two columns wide. ASCII
+// glyphs and the colors are kept.
+func (t *Theme) ASCII() *Theme {
+	ascii := *t
+	fallback := builtins["ascii"].Glyphs
+	if t.CellWidth == 2 {
+		fallback = asciiWide
+	}
+
+	glyphs, fallbacks := ascii.Glyphs.all(), fallback.all()
+	for i, glyph := range glyphs {
+		if !isASCII(*glyph) {
+			*glyph = *fallbacks[i]
+		}
+	}
+	return &ascii
+}
+
+func (g *Glyphs) all() []*string {
+	return []*string{
+		&g.Border, &g.Maze, &g.Empty, &g.SnakeBody, &g.SnakeHead, &g.SnakeHeadUp,
+		&g.SnakeHeadDown, &g.Food, &g.SpeedUp, &g.SlowDown, &g.Ghost,
+		&g.ExtraLength, &g.DoublePoints, &g.Pause, &g.Trail,
+	}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package theme
+
+var classicColors = Colors{
+	Border:     "red",
+	BorderOpen: "green",
+	Maze:       "red",
+	Snake:      "green",
+	SnakeTail:  "22",
+	SpeedUp:    "yellow",
+	SlowDown:   "blue",
+	Timer:      "green",
+	TimerLow:   "red",
+	Highlight:  "yellow",
+}
+
+var builtins = map[string]*Theme{
+	"classic": {
+		Name:      "classic",
+		CellWidth: 2,
+		Glyphs: Glyphs{
+			Border:       "#",
+			Maze:         "##",
+			Empty:        "  ",
+			SnakeBody:    "()",
+			SnakeHead:    ":)",
+			Food:         "🍎",
+			SpeedUp:      "⚡",
+			SlowDown:     "⏳",
+			Ghost:        "👻",
+			ExtraLength:  "🔄",
+			DoublePoints: "💎",
+			Pause:        "⏸",
+			Trail:        "..",
+		},
+		Colors: classicColors,
+	},
+	"ascii": {
+		Name:      "ascii",
+		CellWidth: 1,
+		Glyphs: Glyphs{
+			Border:        "#",
+			Maze:          "#",
+			Empty:         " ",
+			SnakeBody:     "o",
+			SnakeHead:     ">",
+			SnakeHeadUp:   "^",
+			SnakeHeadDown: "v",
+			Food:          "*",
+			SpeedUp:       "+",
+			SlowDown:      "-",
+			Ghost:         "G",
+			ExtraLength:   "L",
+			DoublePoints:  "$",
+			Pause:         "||",
+			Trail:         ".",
+		},
+		Colors: classicColors,
+	},
+	"retro-green": {
+		Name:      "retro-green",
+		CellWidth: 2,
+		Glyphs: Glyphs{
+			Border:       "#",
+			Maze:         "▓▓",
+			Empty:        "  ",
+			SnakeBody:    "[]",
+			SnakeHead:    "[>",
+			Food:         "<>",
+			SpeedUp:      ">>",
+			SlowDown:     "<<",
+			Ghost:        "??",
+			ExtraLength:  "++",
+			DoublePoints: "$$",
+			Pause:        "||",
+			Trail:        "..",
+		},
+		Colors: Colors{
+			Border:     "green",
+			BorderOpen: "bright-green",
+			Maze:       "green",
+			Snake:      "bright-green",
+			SnakeTail:  "22",
+			SpeedUp:    "bright-green",
+			SlowDown:   "green",
+			Food:       "bright-green",
+			PowerUp:    "bright-green",
+			Timer:      "bright-green",
+			TimerLow:   "green",
+			Highlight:  "bright-green",
+		},
+	},
+	"high-contrast": {
+		Name:      "high-contrast",
+		CellWidth: 2,
+		Glyphs: Glyphs{
+			Border:       "█",
+			Maze:         "██",
+			Empty:        "  ",
+			SnakeBody:    "▓▓",
+			SnakeHead:    "██",
+			Food:         "<>",
+			SpeedUp:      ">>",
+			SlowDown:     "<<",
+			Ghost:        "??",
+			ExtraLength:  "++",
+			DoublePoints: "$$",
+			Pause:        "||",
+		},
+		Colors: Colors{
+			Border:     "bright-white",
+			BorderOpen: "bright-cyan",
+			Maze:       "bright-white",
+			Snake:      "bright-yellow",
+			SpeedUp:    "bright-red",
+			SlowDown:   "bright-blue",
+			Food:       "bright-magenta",
+			PowerUp:    "bright-cyan",
+			Timer:      "bright-green",
+			TimerLow:   "bright-red",
+			Highlight:  "bright-yellow",
+		},
+	},
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package theme
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const Reset = "\033[0m"
+
+// Profile is how many colors the terminal can show.
+type Profile int
+
+const (
+	Monochrome Profile = iota
+	Basic              // the 8 ANSI colors and their bright variants
+	ANSI256
+	TrueColor
+)
+
+func (p Profile) String() string {
+	switch p {
+	case Basic:
+		return "8-color"
+	case ANSI256:
+		return "256-color"
+	case TrueColor:
+		return "truecolor"
+	}
+	return "monochrome"
+}
+
+// DetectProfile works out the color support from the environment. A
+// non-empty NO_COLOR always means monochrome, see https://no-color.org.
+func DetectProfile() Profile {
+	if os.Getenv("NO_COLOR") != "" {
+		return Monochrome
+	}
+	term := os.Getenv("TERM")
+	if term == "dumb" {
+		return Monochrome
+	}
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+	if strings.Contains(term, "256color") {
+		return ANSI256
+	}
+	if runtime.GOOS == "windows" && os.Getenv("WT_SESSION") != "" {
+		return TrueColor
+	}
+	return Basic
+}
+
+type rgb struct {
+	r, g, b uint8
+}
+
+// color is a parsed color name. Named colors keep their basic index so
+// they are drawn with the terminal's own palette in every profile.
+type color struct {
+	set   bool
+	basic int // 0-15, or -1
+	index int // 0-255, or -1
+	rgb   rgb
+}
+
+var basicNames = []string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	"bright-black", "bright-red", "bright-green", "bright-yellow",
+	"bright-blue", "bright-magenta", "bright-cyan", "bright-white",
+}
+
+// basicRGB is the xterm default palette, used to blend and match colors.
+var basicRGB = []rgb{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// parseColor accepts "" or "default" (the terminal's color), a basic
+// color name, a 256-color index such as "208", or "#rrggbb".
+func parseColor(name string) (color, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == "default" {
+		return color{basic: -1, index: -1}, nil
+	}
+	for i, basic := range basicNames {
+		if name == basic {
+			return color{set: true, basic: i, index: i, rgb: basicRGB[i]}, nil
+		}
+	}
+	if hex, ok := strings.CutPrefix(name, "#"); ok {
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if len(hex) != 6 || err != nil {
+			return color{}, fmt.Errorf("unknown color %q", name)
+		}
+		return color{set: true, basic: -1, index: -1, rgb: rgb{uint8(v >> 16), uint8(v >> 8), uint8(v)}}, nil
+	}
+	if n, err := strconv.Atoi(name); err == nil && n >= 0 && n <= 255 {
+		c := color{set: true, basic: -1, index: n, rgb: index256RGB(n)}
+		if n < 16 {
+			c.basic = n
+		}
+		return c, nil
+	}
+	return color{}, fmt.Errorf("unknown color %q", name)
+}
+
+func (c color) code(p Profile) string {
+	if !c.set || p == Monochrome {
+		return ""
+	}
+	if c.basic >= 0 {
+		return basicCode(c.basic)
+	}
+	switch p {
+	case TrueColor:
+		return fmt.Sprintf("\033[38;2;%d;%d;%dm", c.rgb.r, c.rgb.g, c.rgb.b)
+	case ANSI256:
+		index := c.index
+		if index < 0 {
+			index = nearest256(c.rgb)
+		}
+		return fmt.Sprintf("\033[38;5;%dm", index)
+	default:
+		return basicCode(nearestBasic(c.rgb))
+	}
+}
+
+func basicCode(i int) string {
+	if i < 8 {
+		return fmt.Sprintf("\033[%dm", 30+i)
+	}
+	return fmt.Sprintf("\033[%dm", 90+i-8)
+}
+
+var cubeLevels = []int{0, 95, 135, 175, 215, 255}
+
+func index256RGB(n int) rgb {
+	switch {
+	case n < 16:
+		return basicRGB[n]
+	case n < 232:
+		n -= 16
+		return rgb{uint8(cubeLevels[n/36]), uint8(cubeLevels[n/6%6]), uint8(cubeLevels[n%6])}
+	default:
+		level := uint8(8 + 10*(n-232))
+		return rgb{level, level, level}
+	}
+}
+
+// nearest256 picks the closest color of the 6x6x6 cube or the gray ramp,
+// leaving out the first 16, which terminals often redefine.
+func nearest256(c rgb) int {
+	best, bestDist := 16, -1
+	for i := 16; i < 256; i++ {
+		if d := distance(c, index256RGB(i)); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+func nearestBasic(c rgb) int {
+	best, bestDist := 0, -1
+	for i, b := range basicRGB {
+		if d := distance(c, b); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+func distance(a, b rgb) int {
+	dr, dg, db := int(a.r)-int(b.r), int(a.g)-int(b.g), int(a.b)-int(b.b)
+	return dr*dr + dg*dg + db*db
+}
+
+// Blend mixes two colors, from at f = 0 and to at f = 1, and returns the
+// result as "#rrggbb". If either color is not set, from is returned.
+func Blend(from, to string, f float64) string {
+	a, errA := parseColor(from)
+	b, errB := parseColor(to)
+	if errA != nil || errB != nil || !a.set || !b.set {
+		return from
+	}
+	f = min(max(f, 0), 1)
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*f + 0.5)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", mix(a.rgb.r, b.rgb.r), mix(a.rgb.g, b.rgb.g), mix(a.rgb.b, b.rgb.b))
+}
+
+// Code returns the escape sequence for a color in the theme's profile.
+func (t *Theme) Code(name string) string {
+	c, err := parseColor(name)
+	if err != nil {
+		return ""
+	}
+	return c.code(t.Profile)
+}
+
+// Paint wraps text in the named color.
+func (t *Theme) Paint(name, text string) string {
+	code := t.Code(name)
+	if code == "" {
+		return text
+	}
+	return code + text + Reset
+}
+
+// Gradients reports whether the terminal has enough colors for blended
+// colors to look smooth.
+func (t *Theme) Gradients() bool {
+	return t.Profile >= ANSI256
+}
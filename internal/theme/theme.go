@@ -0,0 +1,241 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// Package theme holds the glyphs and colors the game is drawn with. Themes
+// are either built in or loaded from TOML files in the themes directory
+// next to the config file.
+package theme
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Theme describes how every kind of cell looks. Each board cell is two
+// terminal columns wide so the board looks square. CellWidth says how wide
+// the glyphs are: 2-column glyphs fill a cell as they are, 1-column glyphs
+// are followed by a space, except walls, which are doubled to stay solid.
+// The border glyph is always a single column.
+type Theme struct {
+	Name      string `toml:"name"`
+	Base      string `toml:"base,omitempty"`
+	CellWidth int    `toml:"cell_width"`
+	Glyphs    Glyphs `toml:"glyphs"`
+	Colors    Colors `toml:"colors"`
+
+	// Profile is what the colors are drawn with. Themes are loaded with
+	// the profile DetectProfile returns.
+	Profile Profile `toml:"-"`
+}
+
+type Glyphs struct {
+	Border        string `toml:"border"`
+	Maze          string `toml:"maze"`
+	Empty         string `toml:"empty"`
+	SnakeBody     string `toml:"snake_body"`
+	SnakeHead     string `toml:"snake_head"`                // facing right
+	SnakeHeadUp   string `toml:"snake_head_up,omitempty"`   // defaults to the body
+	SnakeHeadDown string `toml:"snake_head_down,omitempty"` // defaults to the body
+	Food          string `toml:"food"`
+	SpeedUp       string `toml:"speed_up"`
+	SlowDown      string `toml:"slow_down"`
+	Ghost         string `toml:"ghost"`
+	ExtraLength   string `toml:"extra_length"`
+	DoublePoints  string `toml:"double_points"`
+	Pause         string `toml:"pause"`
+	Trail         string `toml:"trail,omitempty"` // cells the tail just left; none if empty
+}
+
+// Colors are basic color names, 256-color indexes or #rrggbb values, see
+// parseColor. Colors the terminal cannot show are replaced by the nearest
+// one it can.
+type Colors struct {
+	Border     string `toml:"border"`
+	BorderOpen string `toml:"border_open"` // no walls mode and ghost mode
+	Maze       string `toml:"maze"`
+	Snake      string `toml:"snake"`
+	SnakeTail  string `toml:"snake_tail,omitempty"` // the body fades from snake to snake_tail
+	SpeedUp    string `toml:"speed_up"`
+	SlowDown   string `toml:"slow_down"`
+	Food       string `toml:"food"`
+	PowerUp    string `toml:"power_up"`
+	Timer      string `toml:"timer"`
+	TimerLow   string `toml:"timer_low"` // power-ups about to run out
+	Highlight  string `toml:"highlight"`
+}
+
+const Default = "classic"
+
+func Builtin(name string) (*Theme, bool) {
+	t, ok := builtins[name]
+	if !ok {
+		return nil, false
+	}
+	theme := *t
+	theme.Profile = DetectProfile()
+	return &theme, true
+}
+
+// Names lists the built-in themes followed by the user's theme files.
+func Names() []string {
+	var names []string
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dir, err := Dir()
+	if err != nil {
+		return names
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.toml"))
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".toml")
+		if _, ok := builtins[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Dir is where user themes live: themes/ next to config.toml.
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gosnake", "themes"), nil
+}
+
+// Load returns the theme called name: a theme file in Dir, a built-in
+// theme, or, if name ends in .toml, the theme file at that path.
+func Load(name string) (*Theme, error) {
+	if name == "" {
+		name = Default
+	}
+
+	path := name
+	if !strings.HasSuffix(name, ".toml") {
+		dir, err := Dir()
+		if err == nil {
+			path = filepath.Join(dir, name+".toml")
+		}
+		if _, err := os.Stat(path); err != nil {
+			if t, ok := Builtin(name); ok {
+				return t, nil
+			}
+			return nil, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(Names(), ", "))
+		}
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads a theme file. Keys it leaves out come from its base
+// theme, the classic theme unless it names another built-in one.
+func LoadFile(path string) (*Theme, error) {
+	var header struct {
+		Base string `toml:"base"`
+	}
+	if _, err := toml.DecodeFile(path, &header); err != nil {
+		return nil, err
+	}
+	if header.Base == "" {
+		header.Base = Default
+	}
+	t, ok := Builtin(header.Base)
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown base theme %q", path, header.Base)
+	}
+
+	t.Name = strings.TrimSuffix(filepath.Base(path), ".toml")
+	meta, err := toml.DecodeFile(path, t)
+	if err != nil {
+		return nil, err
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("%s: unknown key %q", path, undecoded[0].String())
+	}
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+func (t *Theme) Validate() error {
+	var errs []error
+	if t.CellWidth != 1 && t.CellWidth != 2 {
+		errs = append(errs, fmt.Errorf("cell_width must be 1 or 2, got %d", t.CellWidth))
+	}
+	if utf8.RuneCountInString(t.Glyphs.Border) != 1 {
+		errs = append(errs, fmt.Errorf("glyphs.border must be a single character, got %q", t.Glyphs.Border))
+	}
+	for key, glyph := range map[string]string{
+		"maze": t.Glyphs.Maze, "empty": t.Glyphs.Empty, "snake_body": t.Glyphs.SnakeBody,
+		"snake_head": t.Glyphs.SnakeHead, "food": t.Glyphs.Food, "speed_up": t.Glyphs.SpeedUp,
+		"slow_down": t.Glyphs.SlowDown, "ghost": t.Glyphs.Ghost, "extra_length": t.Glyphs.ExtraLength,
+		"double_points": t.Glyphs.DoublePoints,
+	} {
+		if glyph == "" {
+			errs = append(errs, fmt.Errorf("glyphs.%s must not be empty", key))
+		}
+	}
+	// Board glyphs must fill exactly cell_width columns. The width of
+	// other characters is up to the terminal, so only ASCII is checked.
+	for key, glyph := range map[string]string{
+		"maze": t.Glyphs.Maze, "empty": t.Glyphs.Empty, "snake_body": t.Glyphs.SnakeBody,
+		"snake_head": t.Glyphs.SnakeHead, "snake_head_up": t.Glyphs.SnakeHeadUp,
+		"snake_head_down": t.Glyphs.SnakeHeadDown, "food": t.Glyphs.Food,
+		"speed_up": t.Glyphs.SpeedUp, "slow_down": t.Glyphs.SlowDown, "ghost": t.Glyphs.Ghost,
+		"extra_length": t.Glyphs.ExtraLength, "double_points": t.Glyphs.DoublePoints,
+		"trail": t.Glyphs.Trail,
+	} {
+		width := utf8.RuneCountInString(glyph)
+		if glyph != "" && (width > t.CellWidth || isASCII(glyph) && width != t.CellWidth) {
+			errs = append(errs, fmt.Errorf("glyphs.%s %q does not fit cell_width %d", key, glyph, t.CellWidth))
+		}
+	}
+	for key, name := range map[string]string{
+		"border": t.Colors.Border, "border_open": t.Colors.BorderOpen, "maze": t.Colors.Maze,
+		"snake": t.Colors.Snake, "snake_tail": t.Colors.SnakeTail, "speed_up": t.Colors.SpeedUp,
+		"slow_down": t.Colors.SlowDown, "food": t.Colors.Food, "power_up": t.Colors.PowerUp,
+		"timer": t.Colors.Timer, "timer_low": t.Colors.TimerLow, "highlight": t.Colors.Highlight,
+	} {
+		if _, err := parseColor(name); err != nil {
+			errs = append(errs, fmt.Errorf("colors.%s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Cell pads glyph to the two columns of a board cell.
+func (t *Theme) Cell(glyph string) string {
+	if t.CellWidth == 1 {
+		return glyph + " "
+	}
+	return glyph
+}
+
+// Wall is the maze glyph filling a whole cell.
+func (t *Theme) Wall() string {
+	if t.CellWidth == 1 {
+		return t.Glyphs.Maze + t.Glyphs.Maze
+	}
+	return t.Glyphs.Maze
+}
+
+// Empty is a blank cell.
+func (t *Theme) Empty() string {
+	if t.CellWidth == 1 {
+		return t.Glyphs.Empty + t.Glyphs.Empty
+	}
+	return t.Glyphs.Empty
+}
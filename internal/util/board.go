@@ -0,0 +1,67 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package util
+
+// Board is the playing field, indexed Board[x][y] with x the row.
+type Board [][]Cell
+
+// Cell is one square of the board. Its layers are independent, so food can
+// lie under a snake and a ghost snake can cross a wall without either
+// being lost.
+type Cell struct {
+	Terrain  Terrain
+	Item     Item
+	Occupant Occupant
+}
+
+type Terrain uint8
+
+const (
+	TerrainOpen   Terrain = iota
+	TerrainWall           // a maze obstacle
+	TerrainClosed         // outside the survival arena
+)
+
+type ItemKind uint8
+
+const (
+	ItemNone ItemKind = iota
+	ItemFood
+	ItemPowerUp
+)
+
+// Item is something lying in a cell for a snake to pick up.
+type Item struct {
+	Kind    ItemKind
+	PowerUp PowerUpType // for ItemPowerUp
+}
+
+// Occupant is the snake segment in a cell. Snakes are numbered from 1, so
+// the zero Occupant is no one.
+type Occupant struct {
+	Snake int
+	Seq   int // the snake's move that entered the cell, see Snake.Age
+}
+
+// PlayerSnake is the number of the player's snake.
+const PlayerSnake = 1
+
+// Empty reports whether nothing at all is in the cell.
+func (c Cell) Empty() bool {
+	return c == Cell{}
+}
+
+func (c Cell) Occupied() bool {
+	return c.Occupant.Snake != 0
+}
+
+func (b Board) Clone() Board {
+	clone := make(Board, len(b))
+	for i, row := range b {
+		clone[i] = append([]Cell(nil), row...)
+	}
+	return clone
+}
@@ -8,8 +8,11 @@ package util
 import (
 	"bufio"
 	"fmt"
+	"math/rand/v2"
 	"os"
 	"os/exec"
+	"os/user"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -18,22 +21,68 @@ import (
 )
 
 func NewGameConfig() *GameConfig {
-	width, height := GetBoardSize()
-	offsetX, offsetY := CalculateOffsets()
+	config := DefaultGameConfig()
+	config.TermWidth, config.TermHeight = GetBoardSize()
+	config.OffsetX, config.OffsetY = CalculateOffsets()
+	return config
+}
 
+// DefaultGameConfig is the built-in configuration without anything that
+// depends on the terminal.
+func DefaultGameConfig() *GameConfig {
 	return &GameConfig{
-		TermWidth:  width,
-		TermHeight: height,
-		OffsetX:    offsetX,
-		OffsetY:    offsetY,
-		Speed:      200 * time.Millisecond,
-		BorderChar: "#",
-		MazeChar:   "##",
-		EmptyCell:  "  ",
-		SnakeCell:  "()",
-		SnakeHead:  ":)",
-		FoodCell:   "🍎",
+		Speed:    200 * time.Millisecond,
+		Seed:     rand.Int64(),
+		Duration: DefaultDuration,
+	}
+}
+
+// NewRNG returns the per-game random source. Every random decision of a game
+// goes through it, so the same seed always lays out the same game.
+func NewRNG(seed int64) *rand.Rand {
+	return rand.New(NewSource(seed))
+}
+
+// NewSource is the generator behind NewRNG. Keep it to save and restore
+// the generator's state.
+func NewSource(seed int64) *rand.PCG {
+	return rand.NewPCG(uint64(seed), uint64(seed)^0x9e3779b97f4a7c15)
+}
+
+// SetBoardSize gives the board a fixed size instead of the one derived
+// from the terminal. It refuses sizes that do not fit the current terminal.
+func (c *GameConfig) SetBoardSize(width, height int) error {
+	if width < MinBoardWidth || height < MinBoardHeight {
+		return fmt.Errorf("board %dx%d is too small, the minimum is %dx%d", width, height, MinBoardWidth, MinBoardHeight)
+	}
+	if width > MaxBoardWidth || height > MaxBoardHeight {
+		return fmt.Errorf("board %dx%d is too large, the maximum is %dx%d", width, height, MaxBoardWidth, MaxBoardHeight)
+	}
+	if !BoardFits(width, height) {
+		termWidth, termHeight, _ := TerminalSize()
+		needWidth, needHeight := ScreenSize(width, height)
+		return fmt.Errorf("board %dx%d needs a %dx%d terminal, this one is %dx%d", width, height, needWidth, needHeight, termWidth, termHeight)
+	}
+
+	c.TermWidth, c.TermHeight = width, height
+	c.OffsetX, c.OffsetY = CenterOffsets(width, height)
+	return nil
+}
+
+// Validate reports whether an engine can be built from c, for configs read
+// from files rather than set up by the game itself.
+func (c *GameConfig) Validate() error {
+	if c.TermWidth < MinBoardWidth || c.TermHeight < MinBoardHeight ||
+		c.TermWidth > MaxBoardWidth || c.TermHeight > MaxBoardHeight {
+		return fmt.Errorf("bad board size %dx%d", c.TermWidth, c.TermHeight)
 	}
+	if c.Speed <= 0 {
+		return fmt.Errorf("bad speed %s", c.Speed)
+	}
+	if _, ok := gameModeNames[c.Mode]; !ok {
+		return fmt.Errorf("unknown game mode %d", int(c.Mode))
+	}
+	return nil
 }
 
 func NewSnake() *Snake {
@@ -45,8 +94,16 @@ func NewSnake() *Snake {
 	}
 }
 
+func OppositeDirection(direction int) int {
+	return (direction+1)%4 + 1
+}
+
+func TerminalSize() (int, int, error) {
+	return term.GetSize(int(os.Stdout.Fd()))
+}
+
 func GetBoardSize() (int, int) {
-	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	width, height, err := TerminalSize()
 	if err != nil {
 		fmt.Println("Warning: Unable to determine terminal size, using default 80x24.")
 		width, height = 80, 24
@@ -63,22 +120,44 @@ func GetBoardSize() (int, int) {
 }
 
 func CalculateOffsets() (int, int) {
-	fullWidth, fullHeight, err := term.GetSize(int(os.Stdout.Fd()))
-	termWidth, termHeight := GetBoardSize()
+	return CenterOffsets(GetBoardSize())
+}
+
+// CenterOffsets returns the offsets that center a board of the given size
+// in the current terminal.
+func CenterOffsets(boardWidth, boardHeight int) (int, int) {
+	fullWidth, fullHeight, err := TerminalSize()
 	if err != nil {
 		return 1, 1
 	}
 
-	offsetX := (fullWidth - (termWidth * 2)) / 2
-	offsetY := (fullHeight - termHeight) / 2
+	offsetX := (fullWidth - (boardWidth * 2)) / 2
+	offsetY := (fullHeight - boardHeight) / 2
 
-	return offsetX, offsetY
+	return max(offsetX, 1), max(offsetY, 1)
 }
 
-func InitializeBoard(width, height int) [][]int {
-	board := make([][]int, height)
+// ScreenSize is how many columns and rows the game needs to show a board
+// of the given size with its border, score and status lines.
+func ScreenSize(boardWidth, boardHeight int) (int, int) {
+	return 2 * (boardWidth + 1), boardHeight + 5
+}
+
+// BoardFits reports whether a board of the given size fits in the current
+// terminal. An unknown terminal size is assumed to fit.
+func BoardFits(boardWidth, boardHeight int) bool {
+	fullWidth, fullHeight, err := TerminalSize()
+	if err != nil {
+		return true
+	}
+	needWidth, needHeight := ScreenSize(boardWidth, boardHeight)
+	return fullWidth >= needWidth && fullHeight >= needHeight
+}
+
+func InitializeBoard(width, height int) Board {
+	board := make(Board, height)
 	for i := range board {
-		board[i] = make([]int, width)
+		board[i] = make([]Cell, width)
 	}
 	return board
 }
@@ -107,7 +186,48 @@ func ClearScreen() {
 }
 
 func MoveCursorTo(x, y int) {
-	fmt.Printf("\033[%d;%dH", x, y)
+	fmt.Print(CursorTo(x, y))
+}
+
+// CursorTo returns the escape sequence that moves the cursor to row x,
+// column y (both 1-based).
+func CursorTo(x, y int) string {
+	return fmt.Sprintf("\033[%d;%dH", x, y)
+}
+
+// DataDir is where GoSnake keeps its files: $XDG_DATA_HOME/gosnake, falling
+// back to ~/.local/share/gosnake, or the platform config dir on Windows and macOS.
+func DataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "gosnake"), nil
+	}
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "gosnake"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "gosnake"), nil
+}
+
+// DefaultPlayerName is the name high scores are saved under when the player
+// does not pick one.
+func DefaultPlayerName() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		// Windows usernames come as DOMAIN\user.
+		return u.Username[strings.LastIndex(u.Username, "\\")+1:]
+	}
+	for _, env := range []string{"USER", "USERNAME"} {
+		if name := os.Getenv(env); name != "" {
+			return name
+		}
+	}
+	return "player"
 }
 
 func CheckSpeaker() bool {
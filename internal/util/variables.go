@@ -5,39 +5,90 @@
 
 package util
 
-import "time"
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
 
 type Snake struct {
 	Headx     int
 	Heady     int
 	Direction int // 1 - up, 2 - right, 3 - down, 4 - left
 	Length    int
+	Moves     int // cells the head has entered, counting the first
+}
+
+// Age is how many moves ago the snake's head was at o: 1 is the head,
+// Length the end of the tail.
+func (s Snake) Age(o Occupant) int {
+	return s.Moves - o.Seq + 1
 }
 
 type GameMode int
 
 const (
-	Normal   GameMode = iota
-	NoWalls           // Snake passes through walls
-	Maze              // Has obstacles
-	PowerUps          // Includes power-ups
+	Normal     GameMode = iota
+	NoWalls             // Snake passes through walls
+	Maze                // Has obstacles
+	PowerUps            // Includes power-ups
+	TimeAttack          // Eat as much as possible before the time runs out
+	Survival            // The walls close in over time
 )
 
+var gameModeNames = map[GameMode]string{
+	Normal:     "normal",
+	NoWalls:    "nowalls",
+	Maze:       "maze",
+	PowerUps:   "powerups",
+	TimeAttack: "timeattack",
+	Survival:   "survival",
+}
+
+// GameModes lists every mode in menu order.
+func GameModes() []GameMode {
+	return []GameMode{Normal, NoWalls, Maze, PowerUps, TimeAttack, Survival}
+}
+
+func (m GameMode) String() string {
+	if name, ok := gameModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("mode(%d)", int(m))
+}
+
+func ParseGameMode(name string) (GameMode, error) {
+	for mode, n := range gameModeNames {
+		if n == name {
+			return mode, nil
+		}
+	}
+	return Normal, fmt.Errorf("unknown game mode %q", name)
+}
+
+func (m GameMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *GameMode) UnmarshalText(text []byte) error {
+	mode, err := ParseGameMode(string(text))
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
+
 type GameConfig struct {
 	TermWidth  int
 	TermHeight int
 	OffsetX    int
 	OffsetY    int
 	Speed      time.Duration
-	BorderChar string
-	MazeChar   string
-	EmptyCell  string
-	SnakeCell  string
-	SnakeHead  string
-	FoodCell   string
-	PowerUp    string
 	Mode       GameMode
 	Obstacles  []Position
+	Seed       int64
+	Duration   time.Duration // the time budget in time attack
 }
 
 type Position struct {
@@ -47,8 +98,12 @@ type Position struct {
 type GameState struct {
 	Config      *GameConfig
 	Snake       *Snake
-	Board       [][]int
+	Rand        *rand.Rand
+	Board       Board
 	Score       int
+	Clock       time.Duration // game time: the sum of every tick played
+	Deadline    time.Duration // on the game clock, when time attack ends
+	Inset       int           // rings the survival arena has closed in by
 	ExitGame    bool
 	ExitCode    int
 	PauseGame   bool
@@ -76,23 +131,30 @@ type PowerUp struct {
 	Position Position
 	Duration time.Duration
 	Active   bool
-	EndTime  time.Time
+	EndTime  time.Duration // on the game clock
 }
 
-const (
-	BLACK   = "\033[0m"
-	RED     = "\033[31m"
-	GREEN   = "\033[32m"
-	YELLOW  = "\033[33m"
-	BLUE    = "\033[34m"
-	MAGENTA = "\033[35m"
-	CYAN    = "\033[36m"
-	WHITE   = "\033[37m"
-)
+// Remaining is how long the power-up lasts at the given game clock.
+func (p *PowerUp) Remaining(clock time.Duration) time.Duration {
+	return max(p.EndTime-clock, 0)
+}
 
 const VER = "v0.7"
 const MODSPEED = 15
 
+const (
+	MinBoardWidth  = 10
+	MinBoardHeight = 5
+	MaxBoardWidth  = 500
+	MaxBoardHeight = 500
+)
+
+// Turns pressed faster than the snake moves are queued, up to this many.
+const (
+	DefaultQueueDepth = 3
+	MaxQueueDepth     = 10
+)
+
 const (
 	DirectionUp = iota + 1
 	DirectionRight
@@ -104,4 +166,20 @@ const (
 	CollisionNone = iota
 	CollisionWall
 	CollisionSelf
+	Victory // the snake filled the board
+	TimeUp  // the time attack budget ran out
+)
+
+// Time attack starts with DefaultDuration and every food adds
+// TimeAttackBonus.
+const (
+	DefaultDuration = 120 * time.Second
+	TimeAttackBonus = 5 * time.Second
+)
+
+// In survival the arena closes in by one ring every ShrinkInterval, until
+// it is MinArena cells across.
+const (
+	ShrinkInterval = 15 * time.Second
+	MinArena       = 3
 )